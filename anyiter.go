@@ -1,8 +1,381 @@
 package anyiter
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
 // Iter is the main iterator type
+//
+// An Iter is positioned on a single value. NewIter returns an Iter positioned on the value being iterated, and each
+// call to Next advances to the next element.
 type Iter interface {
+	// Value returns the value the Iter is positioned on.
 	Value() SafeValue
+
+	// Type returns the type of the value the Iter is positioned on.
+	// It returns nil if the value is invalid.
 	Type() SafeType
+
+	// Next returns an Iter positioned on the next element, or nil when iteration is finished.
+	// Calling Next more than once on the same Iter returns the same Iter.
 	Next() Iter
+
+	// Path returns the path from the root of the iteration to the value the Iter is positioned on.
+	Path() Path
+
+	// Depth returns the number of steps from the root of the iteration to the value the Iter is positioned on.
+	// The root has depth 0 and its elements depth 1.
+	Depth() int
+
+	// Parent returns an Iter positioned on the container holding the value the Iter is positioned on.
+	// It returns nil for the root.
+	Parent() Iter
+
+	// Key returns the map key of the value the Iter is positioned on.
+	// It returns the invalid SafeValue if the value is not a map entry.
+	Key() SafeValue
+
+	// Index returns the position of the value the Iter is positioned on within its container: the index of a slice
+	// or array element or struct field, the byte offset of a rune in a string, or the number of values visited before
+	// it in a map or channel.
+	// It returns -1 for the root.
+	Index() int
+
+	// StructField returns the struct field metadata of the value the Iter is positioned on, and a boolean indicating
+	// if the value is a struct field.
+	StructField() (reflect.StructField, bool)
+
+	// Cycle reports whether the value the Iter is positioned on refers back to one of its own ancestors, and returns
+	// the path where that ancestor was visited. Cycles are only reported by a deep walk using ReportCycles.
+	Cycle() (Path, bool)
+
+	// Replace sets the value the Iter is positioned on to x, which must be assignable to its type. Map entries are
+	// replaced in their map; any other value must be settable, like the elements of a slice or the exported fields
+	// of a struct reached through a pointer. A deep walk descends into the replacement if Replace is called before
+	// Next.
+	Replace(x SafeValue) error
+
+	// Err returns the error that ended the iteration, or nil if it finished normally. Like bufio.Scanner's Err, it is
+	// meant to be checked once Next has returned nil; every Iter of one iteration returns the same error.
+	Err() error
+}
+
+// NewIter returns an Iter positioned on v. Calling Next walks the elements of v: the elements of a slice or array,
+// the runes of a string, the values of a map in unspecified order, the values received from a channel until it is
+// closed, or the fields of a struct. Pointers and interfaces are followed to the value they hold.
+//
+// If v can't be iterated, because it is of any other kind, is a nil map, channel, pointer or interface, or is a
+// channel that can't be received from, Next returns nil straight away and Err returns an *IterError.
+func NewIter(v any) Iter {
+	return &iter{value: reflect.ValueOf(v), walker: &flatWalker{}}
+}
+
+type iter struct {
+	value  reflect.Value
+	path   Path
+	field  reflect.StructField
+	parent *iter
+
+	// cycle is the path of the ancestor this iter refers back to, if isCycle is set.
+	cycle   Path
+	isCycle bool
+
+	// skipChildren stops a deep walk from descending into this iter's value.
+	skipChildren bool
+
+	// walker produces the successor of this iter. It is shared by every iter of one iteration.
+	walker   walker
+	next     Iter
+	advanced bool
+}
+
+func (it *iter) Value() SafeValue {
+	return NewSafeValue(it.value)
+}
+
+func (it *iter) Type() SafeType {
+	if !it.value.IsValid() {
+		return nil
+	}
+	return NewSafeType(it.value.Type())
+}
+
+func (it *iter) Next() Iter {
+	if !it.advanced {
+		it.advanced = true
+		if next := it.walker.advance(it); next != nil {
+			it.next = next
+		}
+	}
+	return it.next
+}
+
+func (it *iter) Path() Path {
+	return it.path
+}
+
+func (it *iter) Depth() int {
+	return len(it.path)
+}
+
+func (it *iter) Parent() Iter {
+	if it.parent == nil {
+		return nil
+	}
+	return it.parent
+}
+
+func (it *iter) Key() SafeValue {
+	step, ok := it.step()
+	if !ok || step.Kind != KeyStep {
+		return NewSafeValue(reflect.Value{})
+	}
+	return step.Key
+}
+
+func (it *iter) Index() int {
+	step, ok := it.step()
+	if !ok {
+		return -1
+	}
+	return step.Index
+}
+
+func (it *iter) StructField() (reflect.StructField, bool) {
+	step, ok := it.step()
+	if !ok || step.Kind != FieldStep {
+		return reflect.StructField{}, false
+	}
+	return it.field, true
+}
+
+// step returns the last step of the iter's path, which leads from its parent to it.
+func (it *iter) step() (PathStep, bool) {
+	if len(it.path) == 0 {
+		return PathStep{}, false
+	}
+	return it.path[len(it.path)-1], true
+}
+
+func (it *iter) Cycle() (Path, bool) {
+	return it.cycle, it.isCycle
+}
+
+func (it *iter) Replace(x SafeValue) error {
+	step, ok := it.step()
+	if !ok || step.Kind != KeyStep || it.parent == nil {
+		return NewSafeValue(it.value).Set(x)
+	}
+
+	if !reflectValueOf(x).IsValid() {
+		return errors.New("value to set is invalid")
+	}
+	container := NewSafeValue(indirect(it.parent.value))
+	key := step.Key
+	if err := container.SetMapIndex(key, x); err != nil {
+		return err
+	}
+	it.value = container.ReflectValue().MapIndex(key.ReflectValue())
+	return nil
+}
+
+func (it *iter) Err() error {
+	return it.walker.err()
+}
+
+// IterError is returned by Err when an iteration fails. It records where the failure happened.
+type IterError struct {
+	// Path is the path of the value that couldn't be iterated.
+	Path Path
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *IterError) Error() string {
+	return fmt.Sprintf("%s: %v", pathOrRoot(e.Path), e.Err)
+}
+
+func (e *IterError) Unwrap() error {
+	return e.Err
+}
+
+// walker decides the order in which an iteration visits values.
+type walker interface {
+	// advance returns the iter following from, or nil when the iteration is finished. It is called exactly once for
+	// each iter, in the order the iters were produced.
+	advance(from *iter) *iter
+
+	// err returns the error that ended the iteration, if any.
+	err() error
+}
+
+// flatWalker visits the elements of the root value only.
+type flatWalker struct {
+	root    *iter
+	elems   elements
+	failure error
+}
+
+func (w *flatWalker) advance(from *iter) *iter {
+	// The first call always comes from the root.
+	if w.root == nil {
+		w.root = from
+
+		var err error
+		if w.elems, err = newElements(from.value); err != nil {
+			w.elems = noElements{}
+			w.failure = &IterError{Path: from.path, Err: err}
+		}
+	}
+
+	elem, ok := w.elems.next()
+	if !ok {
+		return nil
+	}
+	return &iter{value: elem.value, path: Path{elem.step}, field: elem.field, parent: w.root, walker: w}
+}
+
+func (w *flatWalker) err() error {
+	return w.failure
+}
+
+// indirect follows pointers and interfaces to the value they hold.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+// elements produces the elements of a single container, in order.
+type elements interface {
+	// next returns the next element, or false once the container is exhausted.
+	next() (element, bool)
+}
+
+// element is an element of a container and the step leading to it.
+type element struct {
+	value reflect.Value
+	step  PathStep
+
+	// field is the struct field metadata of a struct field. It is captured along with the field, because the
+	// container may be replaced before it is asked for.
+	field reflect.StructField
+}
+
+// newElements returns the elements of v, following pointers and interfaces.
+func newElements(v reflect.Value) (elements, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, ErrNilContainer
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		return &indexElements{container: v}, nil
+	case reflect.String:
+		return &runeElements{s: v.String()}, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, ErrNilContainer
+		}
+		return &mapElements{iter: v.MapRange()}, nil
+	case reflect.Chan:
+		if v.IsNil() {
+			return nil, ErrNilContainer
+		}
+		if !v.CanInterface() {
+			return nil, ErrUnexported
+		}
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, fmt.Errorf("%w: channel is send-only", ErrNotIterable)
+		}
+		return &chanElements{container: v}, nil
+	case reflect.Struct:
+		return &fieldElements{container: v}, nil
+	}
+	return nil, ErrNotIterable
+}
+
+type noElements struct{}
+
+func (noElements) next() (element, bool) {
+	return element{}, false
+}
+
+type indexElements struct {
+	container reflect.Value
+	i         int
+}
+
+func (e *indexElements) next() (element, bool) {
+	if e.i >= e.container.Len() {
+		return element{}, false
+	}
+	e.i++
+	return element{value: e.container.Index(e.i - 1), step: PathStep{Kind: IndexStep, Index: e.i - 1}}, true
+}
+
+type runeElements struct {
+	s      string
+	offset int
+}
+
+func (e *runeElements) next() (element, bool) {
+	if e.offset >= len(e.s) {
+		return element{}, false
+	}
+	r, size := utf8.DecodeRuneInString(e.s[e.offset:])
+	e.offset += size
+	return element{value: reflect.ValueOf(r), step: PathStep{Kind: IndexStep, Index: e.offset - size}}, true
+}
+
+type mapElements struct {
+	iter    *reflect.MapIter
+	visited int
+}
+
+func (e *mapElements) next() (element, bool) {
+	// Like a range loop, the iteration skips entries deleted before they are reached, and entries with a NaN key are
+	// visited even though MapIndex can't find them.
+	if !e.iter.Next() {
+		return element{}, false
+	}
+	e.visited++
+	step := PathStep{Kind: KeyStep, Index: e.visited - 1, Key: NewSafeValue(e.iter.Key())}
+	return element{value: e.iter.Value(), step: step}, true
+}
+
+type chanElements struct {
+	container reflect.Value
+	received  int
+}
+
+func (e *chanElements) next() (element, bool) {
+	elem, ok := e.container.Recv()
+	if !ok {
+		return element{}, false
+	}
+	e.received++
+	return element{value: elem, step: PathStep{Kind: IndexStep, Index: e.received - 1}}, true
+}
+
+type fieldElements struct {
+	container reflect.Value
+	i         int
+}
+
+func (e *fieldElements) next() (element, bool) {
+	if e.i >= e.container.NumField() {
+		return element{}, false
+	}
+	e.i++
+	field := e.container.Type().Field(e.i - 1)
+	step := PathStep{Kind: FieldStep, Name: field.Name, Index: e.i - 1}
+	return element{value: e.container.Field(e.i - 1), step: step, field: field}, true
 }
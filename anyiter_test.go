@@ -0,0 +1,306 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// collect returns the interface values of every element after it.
+func collect(it anyiter.Iter) []any {
+	var values []any
+	for it = it.Next(); it != nil; it = it.Next() {
+		v, err := it.Value().Interface()
+		if err != nil {
+			v = it.Value().String()
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestNewIter(t *testing.T) {
+	t.Run("root", func(t *testing.T) {
+		it := anyiter.NewIter([]int{1})
+		assert.Equal(t, reflect.Slice, it.Value().Kind())
+		assert.Equal(t, anyiter.NewSafeType(reflect.TypeOf([]int{})), it.Type())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		it := anyiter.NewIter(nil)
+		assert.False(t, it.Value().IsValid())
+		assert.Nil(t, it.Type())
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNotIterable))
+	})
+
+	t.Run("not iterable", func(t *testing.T) {
+		it := anyiter.NewIter(1)
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNotIterable))
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		assert.Equal(t, []any{1, 2, 3}, collect(anyiter.NewIter([]int{1, 2, 3})))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		it := anyiter.NewIter([]int{})
+		assert.Nil(t, it.Next())
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("array", func(t *testing.T) {
+		assert.Equal(t, []any{"a", "b"}, collect(anyiter.NewIter([2]string{"a", "b"})))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, []any{'h', 'é', 'y'}, collect(anyiter.NewIter("héy")))
+	})
+
+	t.Run("map", func(t *testing.T) {
+		assert.ElementsMatch(t, []any{1, 2}, collect(anyiter.NewIter(map[string]int{"a": 1, "b": 2})))
+	})
+
+	t.Run("NaN key", func(t *testing.T) {
+		it := anyiter.NewIter(map[float64]int{math.NaN(): 1, 2: 2})
+		assert.ElementsMatch(t, []any{1, 2}, collect(it))
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("nil map", func(t *testing.T) {
+		var m map[string]int
+		it := anyiter.NewIter(m)
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNilContainer))
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		assert.Equal(t, []any{1, 2}, collect(anyiter.NewIter(ch)))
+	})
+
+	t.Run("closed channel", func(t *testing.T) {
+		ch := make(chan int)
+		close(ch)
+		it := anyiter.NewIter(ch)
+		assert.Nil(t, it.Next())
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("nil channel", func(t *testing.T) {
+		var ch chan int
+		it := anyiter.NewIter(ch)
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNilContainer))
+	})
+
+	t.Run("send-only channel", func(t *testing.T) {
+		var ch chan<- int = make(chan int)
+		it := anyiter.NewIter(ch)
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNotIterable))
+		assert.Equal(t, "root: value is not iterable: channel is send-only", it.Err().Error())
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		var p *[]int
+		it := anyiter.NewIter(p)
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNilContainer))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type exported struct {
+			A int
+			B string
+		}
+		assert.Equal(t, []any{1, "b"}, collect(anyiter.NewIter(exported{A: 1, B: "b"})))
+	})
+
+	t.Run("unexported struct field", func(t *testing.T) {
+		it := anyiter.NewIter(testStruct{someField: 5}).Next()
+		assert.NotNil(t, it)
+		i, err := it.Value().Int()
+		assert.Equal(t, int64(5), i)
+		assert.Nil(t, err)
+		assert.Equal(t, anyiter.NewSafeType(reflect.TypeOf(1)), it.Type())
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		assert.Equal(t, []any{1, 2}, collect(anyiter.NewIter(&[]int{1, 2})))
+	})
+
+	t.Run("element type", func(t *testing.T) {
+		it := anyiter.NewIter([]any{1}).Next()
+		assert.Equal(t, reflect.Interface, it.Type().Kind())
+	})
+
+	t.Run("next is stable", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		it := anyiter.NewIter(ch)
+		first := it.Next()
+		assert.Same(t, first, it.Next())
+		assert.Equal(t, []any{2}, collect(first))
+	})
+}
+
+func TestIter_Path(t *testing.T) {
+	type exported struct {
+		A int
+	}
+
+	assert.Equal(t, "", anyiter.NewIter([]int{1}).Path().String())
+	assert.Equal(t, "[0]", anyiter.NewIter([]int{1}).Next().Path().String())
+	assert.Equal(t, ".A", anyiter.NewIter(exported{}).Next().Path().String())
+	assert.Equal(t, `["a"]`, anyiter.NewIter(map[string]int{"a": 1}).Next().Path().String())
+	assert.Equal(t, "[1]", anyiter.NewIter("ab").Next().Next().Path().String())
+}
+
+func TestIter_Depth(t *testing.T) {
+	it := anyiter.NewIter([][]int{{1}})
+	assert.Equal(t, 0, it.Depth())
+	assert.Equal(t, 1, it.Next().Depth())
+	assert.Nil(t, it.Next().Next())
+}
+
+func TestIter_Replace(t *testing.T) {
+	t.Run("not settable", func(t *testing.T) {
+		it := anyiter.NewIter([1]int{1}).Next()
+		err := it.Replace(anyiter.NewSafeValue(reflect.ValueOf(2)))
+		assert.Equal(t, anyiter.ErrNotSettable, err)
+	})
+
+	t.Run("slice element", func(t *testing.T) {
+		s := []int{1, 2}
+		it := anyiter.NewIter(s).Next().Next()
+		assert.Nil(t, it.Replace(anyiter.NewSafeValue(reflect.ValueOf(3))))
+		assert.Equal(t, []int{1, 3}, s)
+	})
+
+	t.Run("map entry", func(t *testing.T) {
+		m := map[string]int{"a": 1}
+		it := anyiter.NewIter(m).Next()
+		assert.Nil(t, it.Replace(anyiter.NewSafeValue(reflect.ValueOf(2))))
+		assert.Equal(t, map[string]int{"a": 2}, m)
+		i, _ := it.Value().Int()
+		assert.Equal(t, int64(2), i)
+	})
+
+	t.Run("map entry invalid", func(t *testing.T) {
+		m := map[string]int{"a": 1}
+		err := anyiter.NewIter(m).Next().Replace(nil)
+		assert.Equal(t, errors.New("value to set is invalid"), err)
+	})
+}
+
+func TestIter_Parent(t *testing.T) {
+	root := anyiter.NewIter([]int{1})
+	assert.Nil(t, root.Parent())
+	assert.Equal(t, root, root.Next().Parent())
+
+	deep := anyiter.NewDeepIter([][]int{{1}})
+	leaf := deep.Next().Next()
+	assert.Equal(t, "[0][0]", leaf.Path().String())
+	assert.Equal(t, "[0]", leaf.Parent().Path().String())
+	assert.Equal(t, deep, leaf.Parent().Parent())
+}
+
+func TestIter_Key(t *testing.T) {
+	t.Run("map", func(t *testing.T) {
+		it := anyiter.NewIter(map[string]int{"a": 1}).Next()
+		assert.Equal(t, "a", it.Key().String())
+	})
+
+	t.Run("not a map entry", func(t *testing.T) {
+		assert.False(t, anyiter.NewIter([]int{1}).Next().Key().IsValid())
+		assert.False(t, anyiter.NewIter([]int{1}).Key().IsValid())
+	})
+}
+
+func TestIter_Index(t *testing.T) {
+	type exported struct {
+		A int
+		B int
+	}
+
+	assert.Equal(t, -1, anyiter.NewIter([]int{1}).Index())
+	assert.Equal(t, 1, anyiter.NewIter([]int{1, 2}).Next().Next().Index())
+	assert.Equal(t, 1, anyiter.NewIter(exported{}).Next().Next().Index())
+	assert.Equal(t, 2, anyiter.NewIter("éa").Next().Next().Index())
+	assert.Equal(t, 1, anyiter.NewIter(map[string]int{"a": 1, "b": 2}).Next().Next().Index())
+}
+
+func TestIter_StructField(t *testing.T) {
+	type exported struct {
+		A int `json:"a"`
+	}
+
+	t.Run("field", func(t *testing.T) {
+		field, ok := anyiter.NewIter(&exported{}).Next().StructField()
+		assert.True(t, ok)
+		assert.Equal(t, "A", field.Name)
+		assert.Equal(t, "a", field.Tag.Get("json"))
+	})
+
+	t.Run("not a field", func(t *testing.T) {
+		_, ok := anyiter.NewIter([]int{1}).Next().StructField()
+		assert.False(t, ok)
+		_, ok = anyiter.NewIter(exported{}).StructField()
+		assert.False(t, ok)
+	})
+
+	t.Run("parent replaced", func(t *testing.T) {
+		type inner struct{ X, Y int }
+		type outer struct{ I *inner }
+		var fields []string
+		err := anyiter.Walk(&outer{I: &inner{}}, func(it anyiter.Iter) anyiter.Action {
+			if field, ok := it.StructField(); ok {
+				fields = append(fields, field.Name)
+			}
+			if it.Path().String() == ".I.X" {
+				assert.Nil(t, it.Parent().Replace(anyiter.NewSafeValue(reflect.ValueOf((*inner)(nil)))))
+			}
+			return anyiter.Continue
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"I", "X", "Y"}, fields)
+	})
+}
+
+func TestIter_Err(t *testing.T) {
+	t.Run("finished", func(t *testing.T) {
+		it := anyiter.NewIter([]int{1})
+		assert.NotNil(t, it.Next())
+		assert.Nil(t, it.Next().Next())
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		var m map[string]int
+		it := anyiter.NewIter(m)
+		assert.Nil(t, it.Next())
+
+		var iterErr *anyiter.IterError
+		assert.True(t, errors.As(it.Err(), &iterErr))
+		assert.Equal(t, anyiter.Path(nil), iterErr.Path)
+		assert.Equal(t, anyiter.ErrNilContainer, iterErr.Err)
+		assert.Equal(t, "root: value is nil", it.Err().Error())
+	})
+
+	t.Run("deep walks treat failures as leaves", func(t *testing.T) {
+		var m map[string]int
+		it := anyiter.NewDeepIter([]any{m, 1})
+		assert.Equal(t, []string{"[0]", "[1]"}, collectPaths(it))
+		assert.Nil(t, it.Err())
+	})
+}
@@ -0,0 +1,140 @@
+package anyiter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Bind returns a func that calls fn with args as its first arguments, followed by the arguments it is called with.
+// Its parameters are those of fn that args don't bind. Each of args must be assignable to the parameter it binds.
+// The variadic parameter of a variadic fn can't be bound.
+func Bind(fn SafeFunc, args ...SafeValue) (SafeFunc, error) {
+	at := make([]int, len(args))
+	for i := range args {
+		at[i] = i
+	}
+	return bind("Bind", fn, at, args)
+}
+
+// BindAt returns a func that calls fn with arg as its i'th argument, and the arguments it is called with in the
+// place of the other parameters. arg must be assignable to fn's i'th parameter, which can't be the variadic parameter
+// of a variadic fn.
+func BindAt(fn SafeFunc, i int, arg SafeValue) (SafeFunc, error) {
+	return bind("BindAt", fn, []int{i}, []SafeValue{arg})
+}
+
+// Reorder returns a func whose j'th argument is passed to fn as its order[j]'th argument. order must hold each
+// parameter index of fn exactly once. The variadic parameter of a variadic fn must stay last.
+func Reorder(fn SafeFunc, order ...int) (SafeFunc, error) {
+	if fn.IsNil() {
+		return nil, errors.New("func is nil")
+	}
+
+	t := fn.Type()
+	n, _ := t.NumIn()
+	isVariadic, _ := t.IsVariadic()
+	if len(order) != n {
+		return nil, fmt.Errorf("wrong number of parameter indexes for %s: got %d, want %d", t, len(order), n)
+	}
+
+	seen := make([]bool, n)
+	ins := make([]reflect.Type, n)
+	for j, i := range order {
+		if err := checkIndex("Reorder", i, n); err != nil {
+			return nil, err
+		}
+		if seen[i] {
+			return nil, fmt.Errorf("parameter index %d is repeated", i)
+		}
+		seen[i] = true
+
+		in, _ := t.In(i)
+		ins[j] = in.ReflectType()
+	}
+	if isVariadic && order[n-1] != n-1 {
+		return nil, fmt.Errorf("variadic parameter of %s must stay last", t)
+	}
+
+	return makeFunc(fn, ins, func(args []reflect.Value) []reflect.Value {
+		in := make([]reflect.Value, n)
+		for j, i := range order {
+			in[i] = args[j]
+		}
+		return in
+	})
+}
+
+// bind returns a func that calls fn with args[k] as its at[k]'th argument. op is the operation reported by errors.
+func bind(op string, fn SafeFunc, at []int, args []SafeValue) (SafeFunc, error) {
+	if fn.IsNil() {
+		return nil, errors.New("func is nil")
+	}
+
+	t := fn.Type()
+	n, _ := t.NumIn()
+	isVariadic, _ := t.IsVariadic()
+
+	bound := make([]reflect.Value, n)
+	for k, i := range at {
+		if err := checkIndex(op, i, n); err != nil {
+			return nil, err
+		}
+		if isVariadic && i == n-1 {
+			return nil, fmt.Errorf("variadic parameter of %s can't be bound", t)
+		}
+
+		param, _ := t.In(i)
+		arg := reflectValueOf(args[k])
+		if !arg.IsValid() {
+			return nil, fmt.Errorf("argument for parameter %d is invalid", i)
+		}
+		if !arg.CanInterface() {
+			return nil, fmt.Errorf("argument for parameter %d: %w", i, ErrUnexported)
+		}
+		if !NewSafeType(arg.Type()).AssignableTo(param) {
+			return nil, fmt.Errorf("argument for parameter %d: %s is not assignable to %s", i, arg.Type(), param)
+		}
+		bound[i] = arg
+	}
+
+	// The parameters of the new func are the unbound ones, in order.
+	var free []int
+	var ins []reflect.Type
+	for i := range bound {
+		if !bound[i].IsValid() {
+			param, _ := t.In(i)
+			free = append(free, i)
+			ins = append(ins, param.ReflectType())
+		}
+	}
+
+	return makeFunc(fn, ins, func(args []reflect.Value) []reflect.Value {
+		in := make([]reflect.Value, n)
+		copy(in, bound)
+		for j, i := range free {
+			in[i] = args[j]
+		}
+		return in
+	})
+}
+
+// makeFunc returns a func with parameters of types ins and fn's results, which calls fn with the arguments
+// arrange returns for its own.
+func makeFunc(fn SafeFunc, ins []reflect.Type, arrange func([]reflect.Value) []reflect.Value) (SafeFunc, error) {
+	t := fn.Type().ReflectType()
+	outs := make([]reflect.Type, t.NumOut())
+	for i := range outs {
+		outs[i] = t.Out(i)
+	}
+
+	f := fn.ReflectValue()
+	made := reflect.MakeFunc(reflect.FuncOf(ins, outs, t.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		// A variadic func receives its variadic arguments as a slice, which is passed on as is.
+		if t.IsVariadic() {
+			return f.CallSlice(arrange(args))
+		}
+		return f.Call(arrange(args))
+	})
+	return NewSafeFunc(made)
+}
@@ -0,0 +1,157 @@
+package anyiter_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func mustSafeFunc(fn any) anyiter.SafeFunc {
+	f, err := anyiter.NewSafeFunc(reflect.ValueOf(fn))
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TestBind(t *testing.T) {
+	greet := mustSafeFunc(func(greeting, name string, times int) string {
+		return fmt.Sprintf("%s %s x%d", greeting, name, times)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		fn, err := anyiter.Bind(greet, safeValues("hello", "bob")...)
+		assert.Nil(t, err)
+		assert.Equal(t, reflect.TypeOf(func(int) string { return "" }), fn.Type().ReflectType())
+
+		out, err := fn.Call(safeValues(2))
+		assert.Nil(t, err)
+		assert.Equal(t, "hello bob x2", out[0].String())
+	})
+
+	t.Run("all", func(t *testing.T) {
+		fn, err := anyiter.Bind(greet, safeValues("hi", "al", 1)...)
+		assert.Nil(t, err)
+		out, _ := fn.Call(nil)
+		assert.Equal(t, "hi al x1", out[0].String())
+	})
+
+	t.Run("variadic", func(t *testing.T) {
+		sum := mustSafeFunc(func(base int, vals ...int) int {
+			for _, val := range vals {
+				base += val
+			}
+			return base
+		})
+		fn, err := anyiter.Bind(sum, safeValues(10)...)
+		assert.Nil(t, err)
+		out, err := fn.Call(safeValues(1, 2))
+		assert.Nil(t, err)
+		assert.Equal(t, 13, out[0].ReflectValue().Interface())
+
+		_, err = anyiter.Bind(sum, safeValues(1, []int{2})...)
+		assert.Equal(t, errors.New("variadic parameter of func(int, ...int) int can't be bound"), err)
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+		_, err := anyiter.Bind(greet, safeValues("a", "b", 1, 2)...)
+		assertIndexError(t, err, "Bind")
+	})
+
+	t.Run("unassignable argument", func(t *testing.T) {
+		_, err := anyiter.Bind(greet, safeValues("a", 1)...)
+		assert.Equal(t, errors.New("argument for parameter 1: int is not assignable to string"), err)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		_, err := anyiter.Bind(greet, nil)
+		assert.Equal(t, errors.New("argument for parameter 0 is invalid"), err)
+	})
+
+	t.Run("unexported argument", func(t *testing.T) {
+		type holder struct{ greeting string }
+		field, _ := anyiter.NewSafeValue(reflect.ValueOf(holder{"hi"})).Field(0)
+		_, err := anyiter.Bind(greet, field)
+		assert.True(t, errors.Is(err, anyiter.ErrUnexported))
+		assert.EqualError(t, err, "argument for parameter 0: value was obtained using an unexported field")
+	})
+
+	t.Run("nil func", func(t *testing.T) {
+		var nilFn func()
+		_, err := anyiter.Bind(mustSafeFunc(nilFn))
+		assert.Equal(t, errors.New("func is nil"), err)
+	})
+}
+
+func TestBindAt(t *testing.T) {
+	div := mustSafeFunc(func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		half, err := anyiter.BindAt(div, 1, anyiter.NewSafeValue(reflect.ValueOf(2)))
+		assert.Nil(t, err)
+		assert.Equal(t, reflect.TypeOf(func(int) (int, error) { return 0, nil }), half.Type().ReflectType())
+
+		out, err := half.Call(safeValues(10))
+		assert.Nil(t, err)
+		assert.Equal(t, 5, out[0].ReflectValue().Interface())
+		assert.True(t, out[1].ReflectValue().IsNil())
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.BindAt(div, -1, anyiter.NewSafeValue(reflect.ValueOf(2)))
+		assertIndexError(t, err, "BindAt")
+	})
+}
+
+func TestReorder(t *testing.T) {
+	sub := mustSafeFunc(func(a, b int) int { return a - b })
+
+	t.Run("valid", func(t *testing.T) {
+		fn, err := anyiter.Reorder(sub, 1, 0)
+		assert.Nil(t, err)
+		out, err := fn.Call(safeValues(1, 10))
+		assert.Nil(t, err)
+		assert.Equal(t, 9, out[0].ReflectValue().Interface())
+	})
+
+	t.Run("types", func(t *testing.T) {
+		fn, err := anyiter.Reorder(mustSafeFunc(func(string, int) {}), 1, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, reflect.TypeOf(func(int, string) {}), fn.Type().ReflectType())
+	})
+
+	t.Run("wrong number of indexes", func(t *testing.T) {
+		_, err := anyiter.Reorder(sub, 0)
+		assert.Equal(t, errors.New("wrong number of parameter indexes for func(int, int) int: got 1, want 2"), err)
+	})
+
+	t.Run("repeated index", func(t *testing.T) {
+		_, err := anyiter.Reorder(sub, 0, 0)
+		assert.Equal(t, errors.New("parameter index 0 is repeated"), err)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.Reorder(sub, 0, 2)
+		assertIndexError(t, err, "Reorder")
+	})
+
+	t.Run("variadic", func(t *testing.T) {
+		join := mustSafeFunc(func(a string, b int, rest ...string) string { return fmt.Sprint(a, b, rest) })
+		fn, err := anyiter.Reorder(join, 1, 0, 2)
+		assert.Nil(t, err)
+		out, err := fn.Call(safeValues(1, "a", "x", "y"))
+		assert.Nil(t, err)
+		assert.Equal(t, "a1 [x y]", out[0].String())
+
+		_, err = anyiter.Reorder(join, 2, 1, 0)
+		assert.Equal(t, errors.New("variadic parameter of func(string, int, ...string) string must stay last"), err)
+	})
+}
@@ -0,0 +1,133 @@
+package anyiter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CallMethod calls the exported method called name on receiver with args, and returns its results.
+//
+// Every argument is checked against the method's signature before the call. An argument that isn't assignable to
+// its parameter is converted if both are numeric and the conversion doesn't lose information, so an int literal can
+// be passed for an int64 or float64 parameter. A nil argument is passed as the zero value of a parameter that can
+// be nil. If the method panics, the panic is returned as an error.
+func CallMethod(receiver any, name string, args ...any) ([]SafeValue, error) {
+	rv := reflect.ValueOf(receiver)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("method %s: receiver is nil", name)
+	}
+
+	m, ok := NewSafeType(rv.Type()).MethodByName(name)
+	if !ok {
+		if rv.Kind() != reflect.Ptr {
+			if _, ok := reflect.PointerTo(rv.Type()).MethodByName(name); ok {
+				return nil, fmt.Errorf("method %s: %w in method set of %s, only of %s", name, ErrNotFound, rv.Type(),
+					reflect.PointerTo(rv.Type()))
+			}
+		}
+		return nil, fmt.Errorf("method %s: %w in method set of %s", name, ErrNotFound, rv.Type())
+	}
+
+	fn, err := m.Func()
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", name, err)
+	}
+
+	// The receiver is the method's first parameter.
+	t := m.Type().ReflectType()
+	n := t.NumIn() - 1
+	if t.IsVariadic() {
+		if len(args) < n-1 {
+			return nil, fmt.Errorf("method %s: got %d arguments, want at least %d", name, len(args), n-1)
+		}
+	} else if len(args) != n {
+		return nil, fmt.Errorf("method %s: got %d arguments, want %d", name, len(args), n)
+	}
+
+	in := make([]SafeValue, len(args)+1)
+	in[0] = NewSafeValue(rv)
+	for i, arg := range args {
+		v, err := coerce(reflect.ValueOf(arg), paramType(t, i+1, false))
+		if err != nil {
+			return nil, fmt.Errorf("method %s: argument %d: %w", name, i, err)
+		}
+		in[i+1] = NewSafeValue(v)
+	}
+
+	var out []SafeValue
+	if err := catchPanic(func() { out, err = fn.Call(in) }); err != nil {
+		return nil, fmt.Errorf("method %s: panicked: %w", name, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", name, err)
+	}
+	return out, nil
+}
+
+// coerce returns v as a value of type t, if it is assignable to t or a numeric conversion to t keeps its value. Floats
+// convert to another float type if they're within its range, rounding if needed; any other numeric conversion must be
+// lossless. An invalid v, from a nil argument, becomes the zero value of t if t can be nil.
+func coerce(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if !v.IsValid() {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice,
+			reflect.UnsafePointer:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("nil is not assignable to %s", t)
+	}
+
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if isNumeric(v.Kind()) && isNumeric(t.Kind()) && v.Type().ConvertibleTo(t) {
+		converted := v.Convert(t)
+		if v.CanFloat() && converted.CanFloat() {
+			if !converted.OverflowFloat(v.Float()) {
+				return converted, nil
+			}
+			return reflect.Value{}, fmt.Errorf("%v can't be represented as %s", v, t)
+		}
+		// A conversion is lossless if it keeps the sign and converting back gives the original value.
+		if sign(converted) == sign(v) && converted.Convert(v.Type()).Interface() == v.Interface() {
+			return converted, nil
+		}
+		return reflect.Value{}, fmt.Errorf("%v can't be represented as %s", v, t)
+	}
+	return reflect.Value{}, fmt.Errorf("%s is not assignable to %s", v.Type(), t)
+}
+
+func isNumeric(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// sign returns -1, 0 or 1 for a negative, zero or positive numeric value.
+func sign(v reflect.Value) int {
+	switch {
+	case v.CanInt():
+		if i := v.Int(); i != 0 {
+			if i < 0 {
+				return -1
+			}
+			return 1
+		}
+	case v.CanUint():
+		if v.Uint() != 0 {
+			return 1
+		}
+	case v.CanFloat():
+		if f := v.Float(); f != 0 {
+			if f < 0 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
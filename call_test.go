@@ -0,0 +1,136 @@
+package anyiter_test
+
+import (
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+type testService struct {
+	total int64
+}
+
+func (s *testService) Add(n int64) int64 {
+	s.total += n
+	return s.total
+}
+
+func (s testService) Sum(base float64, vals ...int) float64 {
+	for _, val := range vals {
+		base += float64(val)
+	}
+	return base
+}
+
+func (testService) Describe(names []string, err error) int {
+	if err != nil {
+		return -1
+	}
+	return len(names)
+}
+
+func (testService) F32(f float32) float32 {
+	return f
+}
+
+func (testService) Fail() {
+	panic("boom")
+}
+
+func TestCallMethod(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := &testService{total: 1}
+		out, err := anyiter.CallMethod(s, "Add", int64(2))
+		assert.Nil(t, err)
+		assert.Len(t, out, 1)
+		i, _ := out[0].Int()
+		assert.Equal(t, int64(3), i)
+		assert.Equal(t, int64(3), s.total)
+	})
+
+	t.Run("variadic", func(t *testing.T) {
+		out, err := anyiter.CallMethod(testService{}, "Sum", 1, 2, 3)
+		assert.Nil(t, err)
+		f, _ := out[0].Float()
+		assert.Equal(t, 6.0, f)
+
+		out, err = anyiter.CallMethod(testService{}, "Sum", 1.5)
+		assert.Nil(t, err)
+		f, _ = out[0].Float()
+		assert.Equal(t, 1.5, f)
+	})
+
+	t.Run("converts numbers", func(t *testing.T) {
+		out, err := anyiter.CallMethod(&testService{}, "Add", 5)
+		assert.Nil(t, err)
+		i, _ := out[0].Int()
+		assert.Equal(t, int64(5), i)
+	})
+
+	t.Run("rounds floats", func(t *testing.T) {
+		for _, val := range []float64{0.1, 3.14, math.NaN()} {
+			out, err := anyiter.CallMethod(testService{}, "F32", val)
+			assert.Nil(t, err)
+			f, _ := out[0].Float()
+			if math.IsNaN(val) {
+				assert.True(t, math.IsNaN(f))
+			} else {
+				assert.Equal(t, float64(float32(val)), f)
+			}
+		}
+
+		_, err := anyiter.CallMethod(testService{}, "F32", 1e300)
+		assert.EqualError(t, err, "method F32: argument 0: 1e+300 can't be represented as float32")
+	})
+
+	t.Run("lossy conversion", func(t *testing.T) {
+		_, err := anyiter.CallMethod(testService{}, "Sum", 0, 1.5)
+		assert.EqualError(t, err, "method Sum: argument 1: 1.5 can't be represented as int")
+
+		_, err = anyiter.CallMethod(&testService{}, "Add", uint64(1<<63))
+		assert.EqualError(t, err, "method Add: argument 0: 9223372036854775808 can't be represented as int64")
+	})
+
+	t.Run("nil arguments", func(t *testing.T) {
+		out, err := anyiter.CallMethod(testService{}, "Describe", nil, nil)
+		assert.Nil(t, err)
+		i, _ := out[0].Int()
+		assert.Equal(t, int64(0), i)
+
+		_, err = anyiter.CallMethod(&testService{}, "Add", nil)
+		assert.EqualError(t, err, "method Add: argument 0: nil is not assignable to int64")
+	})
+
+	t.Run("not assignable", func(t *testing.T) {
+		_, err := anyiter.CallMethod(&testService{}, "Add", "1")
+		assert.EqualError(t, err, "method Add: argument 0: string is not assignable to int64")
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		_, err := anyiter.CallMethod(&testService{}, "Add")
+		assert.EqualError(t, err, "method Add: got 0 arguments, want 1")
+
+		_, err = anyiter.CallMethod(testService{}, "Sum")
+		assert.EqualError(t, err, "method Sum: got 0 arguments, want at least 1")
+	})
+
+	t.Run("pointer receiver", func(t *testing.T) {
+		_, err := anyiter.CallMethod(testService{}, "Add", 1)
+		assert.EqualError(t, err, "method Add: not found in method set of anyiter_test.testService, "+
+			"only of *anyiter_test.testService")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := anyiter.CallMethod(testService{}, "Missing")
+		assert.EqualError(t, err, "method Missing: not found in method set of anyiter_test.testService")
+
+		_, err = anyiter.CallMethod(nil, "Missing")
+		assert.EqualError(t, err, "method Missing: receiver is nil")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		_, err := anyiter.CallMethod(testService{}, "Fail")
+		assert.EqualError(t, err, "method Fail: panicked: boom")
+	})
+}
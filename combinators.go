@@ -0,0 +1,347 @@
+package anyiter
+
+import "reflect"
+
+// The combinators below build iterations from other iterations. Each takes an Iter positioned before a sequence of
+// elements, like the result of NewIter, and returns an Iter positioned before the derived sequence. The elements of
+// a derived sequence behave like the elements they were derived from, apart from where their Next leads, and Err
+// passes along the error of every iteration the combinator read from.
+
+// Filter returns the elements after it for which keep returns true.
+func Filter(it Iter, keep func(Iter) bool) Iter {
+	return newDerived(it, &filter{source: newSource(it), keep: keep})
+}
+
+// Map returns the elements after it with their values replaced by the result of f. The Type of each element is the
+// type of its new value. If f returns an error, the iteration ends and Err returns an *IterError wrapping it.
+func Map(it Iter, f func(Iter) (SafeValue, error)) Iter {
+	return newDerived(it, &mapper{source: newSource(it), f: f})
+}
+
+// Take returns the first n elements after it.
+func Take(it Iter, n int) Iter {
+	return newDerived(it, &taker{source: newSource(it), left: n})
+}
+
+// TakeWhile returns the elements after it up to the first one for which keep returns false.
+func TakeWhile(it Iter, keep func(Iter) bool) Iter {
+	return newDerived(it, &whileTaker{source: newSource(it), keep: keep})
+}
+
+// Skip returns the elements after it, apart from the first n.
+func Skip(it Iter, n int) Iter {
+	return newDerived(it, &skipper{source: newSource(it), left: n})
+}
+
+// Chain returns the elements after each of its in turn. The result is positioned on the value of its[0].
+func Chain(its ...Iter) Iter {
+	if len(its) == 0 {
+		return newDerived(emptyIter(), &chain{})
+	}
+	return newDerived(its[0], &chain{sources: its, cur: its[0]})
+}
+
+// Zip returns pairs of the elements after a and b, ending with the shorter of the two. The Key of each pair is the
+// value of the element of a, and its Value is the value of the element of b.
+func Zip(a, b Iter) Iter {
+	return newDerived(a, &zipper{a: newSource(a), b: newSource(b)})
+}
+
+// FlatMap returns the elements after each of the Iters f returns for the elements after it. A nil Iter from f
+// contributes no elements.
+func FlatMap(it Iter, f func(Iter) Iter) Iter {
+	return newDerived(it, &flatMapper{source: newSource(it), f: f})
+}
+
+// Enumerate returns the elements after it, with the Key of each element replaced by its position in the sequence
+// as an int.
+func Enumerate(it Iter) Iter {
+	return newDerived(it, &enumerator{source: newSource(it)})
+}
+
+// emptyIter returns an Iter positioned on the invalid value, with no elements.
+func emptyIter() Iter {
+	w := &flatWalker{elems: noElements{}}
+	w.root = &iter{walker: w}
+	return w.root
+}
+
+// combinator produces the elements of a derived iteration.
+type combinator interface {
+	// advance returns the next element, or nil when the iteration is finished. It is called exactly once for each
+	// element, in order.
+	advance() *derived
+
+	// err returns the error that ended the iteration, if any.
+	err() error
+}
+
+// derived is an element of a derived iteration. It behaves like the element it was derived from, apart from where
+// Next leads and any key or value the combinator replaced.
+type derived struct {
+	Iter
+
+	// key and value, if set, replace those of the element.
+	key   SafeValue
+	value SafeValue
+
+	combinator combinator
+	next       Iter
+	advanced   bool
+}
+
+func newDerived(head Iter, c combinator) Iter {
+	return &derived{Iter: head, combinator: c}
+}
+
+func (d *derived) Value() SafeValue {
+	if d.value != nil {
+		return d.value
+	}
+	return d.Iter.Value()
+}
+
+func (d *derived) Type() SafeType {
+	if d.value != nil {
+		t, err := d.value.Type()
+		if err != nil {
+			return nil
+		}
+		return t
+	}
+	return d.Iter.Type()
+}
+
+func (d *derived) Key() SafeValue {
+	if d.key != nil {
+		return d.key
+	}
+	return d.Iter.Key()
+}
+
+func (d *derived) Next() Iter {
+	if !d.advanced {
+		d.advanced = true
+		if next := d.combinator.advance(); next != nil {
+			next.combinator = d.combinator
+			d.next = next
+		}
+	}
+	return d.next
+}
+
+func (d *derived) Err() error {
+	return d.combinator.err()
+}
+
+// source is the iteration a combinator reads from.
+type source struct {
+	// head is where the iteration started, and cur the element last read from it.
+	head Iter
+	cur  Iter
+}
+
+func newSource(it Iter) source {
+	return source{head: it, cur: it}
+}
+
+// err returns the error of the source. Every Iter of an iteration returns the same error, but cur is nil once the
+// source is exhausted, so the head is asked.
+func (s *source) err() error {
+	return s.head.Err()
+}
+
+type filter struct {
+	source
+	keep func(Iter) bool
+}
+
+func (c *filter) advance() *derived {
+	for c.cur = c.cur.Next(); c.cur != nil; c.cur = c.cur.Next() {
+		if c.keep(c.cur) {
+			return &derived{Iter: c.cur}
+		}
+	}
+	return nil
+}
+
+type mapper struct {
+	source
+	f       func(Iter) (SafeValue, error)
+	failure error
+}
+
+func (c *mapper) advance() *derived {
+	if c.cur = c.cur.Next(); c.cur == nil {
+		return nil
+	}
+
+	value, err := c.f(c.cur)
+	if err != nil {
+		c.failure = &IterError{Path: c.cur.Path(), Err: err}
+		return nil
+	}
+	return &derived{Iter: c.cur, value: NewSafeValue(reflectValueOf(value))}
+}
+
+func (c *mapper) err() error {
+	if c.failure != nil {
+		return c.failure
+	}
+	return c.source.err()
+}
+
+type taker struct {
+	source
+	left int
+}
+
+func (c *taker) advance() *derived {
+	// Once n elements are taken the source isn't advanced again, so nothing more is received from a channel.
+	if c.left <= 0 {
+		return nil
+	}
+	c.left--
+
+	if c.cur = c.cur.Next(); c.cur == nil {
+		return nil
+	}
+	return &derived{Iter: c.cur}
+}
+
+type whileTaker struct {
+	source
+	keep func(Iter) bool
+	done bool
+}
+
+func (c *whileTaker) advance() *derived {
+	if c.done {
+		return nil
+	}
+
+	if c.cur = c.cur.Next(); c.cur == nil || !c.keep(c.cur) {
+		c.done = true
+		return nil
+	}
+	return &derived{Iter: c.cur}
+}
+
+type skipper struct {
+	source
+	left int
+}
+
+func (c *skipper) advance() *derived {
+	for c.cur = c.cur.Next(); c.cur != nil; c.cur = c.cur.Next() {
+		if c.left <= 0 {
+			return &derived{Iter: c.cur}
+		}
+		c.left--
+	}
+	return nil
+}
+
+type chain struct {
+	sources []Iter
+	cur     Iter
+	failure error
+}
+
+func (c *chain) advance() *derived {
+	for len(c.sources) > 0 {
+		if c.cur = c.cur.Next(); c.cur != nil {
+			return &derived{Iter: c.cur}
+		}
+
+		// A failed source ends the whole chain.
+		if c.failure = c.sources[0].Err(); c.failure != nil {
+			return nil
+		}
+
+		c.sources = c.sources[1:]
+		if len(c.sources) > 0 {
+			c.cur = c.sources[0]
+		}
+	}
+	return nil
+}
+
+func (c *chain) err() error {
+	if c.failure != nil {
+		return c.failure
+	}
+	if len(c.sources) > 0 {
+		return c.sources[0].Err()
+	}
+	return nil
+}
+
+type zipper struct {
+	a, b source
+}
+
+func (c *zipper) advance() *derived {
+	if c.a.cur = c.a.cur.Next(); c.a.cur == nil {
+		return nil
+	}
+	if c.b.cur = c.b.cur.Next(); c.b.cur == nil {
+		return nil
+	}
+	return &derived{Iter: c.b.cur, key: c.a.cur.Value()}
+}
+
+func (c *zipper) err() error {
+	if err := c.a.err(); err != nil {
+		return err
+	}
+	return c.b.err()
+}
+
+type flatMapper struct {
+	source
+	inner   Iter
+	f       func(Iter) Iter
+	failure error
+}
+
+func (c *flatMapper) advance() *derived {
+	for {
+		if c.inner != nil {
+			last := c.inner
+			if c.inner = c.inner.Next(); c.inner != nil {
+				return &derived{Iter: c.inner}
+			}
+
+			// A failed inner iteration ends the whole iteration.
+			if c.failure = last.Err(); c.failure != nil {
+				return nil
+			}
+		}
+
+		if c.cur = c.cur.Next(); c.cur == nil {
+			return nil
+		}
+		c.inner = c.f(c.cur)
+	}
+}
+
+func (c *flatMapper) err() error {
+	if c.failure != nil {
+		return c.failure
+	}
+	return c.source.err()
+}
+
+type enumerator struct {
+	source
+	count int
+}
+
+func (c *enumerator) advance() *derived {
+	if c.cur = c.cur.Next(); c.cur == nil {
+		return nil
+	}
+	c.count++
+	return &derived{Iter: c.cur, key: NewSafeValue(reflect.ValueOf(c.count - 1))}
+}
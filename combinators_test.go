@@ -0,0 +1,188 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		it := anyiter.Filter(anyiter.NewIter([]int{1, 2, 3, 4}), func(it anyiter.Iter) bool {
+			i, _ := it.Value().Int()
+			return i%2 == 0
+		})
+		assert.Equal(t, []any{2, 4}, collect(it))
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("sensitive string fields", func(t *testing.T) {
+		type config struct {
+			User     string
+			Password string `sensitive:"true"`
+			Port     int    `sensitive:"true"`
+		}
+		it := anyiter.Filter(anyiter.NewIter(config{"admin", "hunter2", 22}), func(it anyiter.Iter) bool {
+			field, _ := it.StructField()
+			_, sensitive := field.Tag.Lookup("sensitive")
+			return it.Type().Kind() == reflect.String && sensitive
+		})
+		assert.Equal(t, []any{"hunter2"}, collect(it))
+	})
+
+	t.Run("keeps element accessors", func(t *testing.T) {
+		it := anyiter.Filter(anyiter.NewIter([]string{"a", "b"}), func(it anyiter.Iter) bool {
+			return it.Index() == 1
+		}).Next()
+		assert.Equal(t, "[1]", it.Path().String())
+		assert.Equal(t, 1, it.Depth())
+		assert.NotNil(t, it.Parent())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var m map[string]int
+		it := anyiter.Filter(anyiter.NewIter(m), func(anyiter.Iter) bool { return true })
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNilContainer))
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		it := anyiter.Map(anyiter.NewIter([]int{1, 2}), func(it anyiter.Iter) (anyiter.SafeValue, error) {
+			i, _ := it.Value().Int()
+			return anyiter.NewSafeValue(reflect.ValueOf(float64(i) / 2)), nil
+		})
+		assert.Equal(t, []any{0.5, 1.0}, collect(it))
+		assert.Equal(t, reflect.Float64, it.Next().Type().Kind())
+		assert.Equal(t, "[0]", it.Next().Path().String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		failure := errors.New("odd value")
+		it := anyiter.Map(anyiter.NewIter([]int{2, 3, 4}), func(it anyiter.Iter) (anyiter.SafeValue, error) {
+			if i, _ := it.Value().Int(); i%2 != 0 {
+				return nil, failure
+			}
+			return it.Value(), nil
+		})
+		assert.Equal(t, []any{2}, collect(it))
+
+		var iterErr *anyiter.IterError
+		assert.True(t, errors.As(it.Err(), &iterErr))
+		assert.Equal(t, "[1]", iterErr.Path.String())
+		assert.Equal(t, failure, iterErr.Err)
+	})
+}
+
+func TestTake(t *testing.T) {
+	assert.Equal(t, []any{1, 2}, collect(anyiter.Take(anyiter.NewIter([]int{1, 2, 3}), 2)))
+	assert.Equal(t, []any{1}, collect(anyiter.Take(anyiter.NewIter([]int{1}), 2)))
+	assert.Nil(t, collect(anyiter.Take(anyiter.NewIter([]int{1}), 0)))
+
+	t.Run("stops receiving", func(t *testing.T) {
+		c := make(chan int, 3)
+		c <- 1
+		c <- 2
+		c <- 3
+		close(c)
+		assert.Equal(t, []any{1, 2}, collect(anyiter.Take(anyiter.NewIter(c), 2)))
+		assert.Equal(t, 3, <-c)
+	})
+}
+
+func TestTakeWhile(t *testing.T) {
+	it := anyiter.TakeWhile(anyiter.NewIter([]int{1, 2, 3, 1}), func(it anyiter.Iter) bool {
+		i, _ := it.Value().Int()
+		return i < 3
+	})
+	assert.Equal(t, []any{1, 2}, collect(it))
+}
+
+func TestSkip(t *testing.T) {
+	assert.Equal(t, []any{3}, collect(anyiter.Skip(anyiter.NewIter([]int{1, 2, 3}), 2)))
+	assert.Nil(t, collect(anyiter.Skip(anyiter.NewIter([]int{1, 2}), 3)))
+	assert.Equal(t, []any{1}, collect(anyiter.Skip(anyiter.NewIter([]int{1}), 0)))
+}
+
+func TestChain(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		it := anyiter.Chain(anyiter.NewIter([]int{1}), anyiter.NewIter([]int{}), anyiter.NewIter("ab"))
+		assert.Equal(t, []any{1, 'a', 'b'}, collect(it))
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		it := anyiter.Chain()
+		assert.Nil(t, it.Next())
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var m map[string]int
+		it := anyiter.Chain(anyiter.NewIter([]int{1}), anyiter.NewIter(m), anyiter.NewIter([]int{2}))
+		assert.Equal(t, []any{1}, collect(it))
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNilContainer))
+	})
+}
+
+func TestZip(t *testing.T) {
+	t.Run("pairs", func(t *testing.T) {
+		it := anyiter.Zip(anyiter.NewIter([]string{"a", "b", "c"}), anyiter.NewIter([]int{1, 2}))
+		var keys []any
+		for next := it.Next(); next != nil; next = next.Next() {
+			k, _ := next.Key().Interface()
+			keys = append(keys, k)
+		}
+		assert.Equal(t, []any{"a", "b"}, keys)
+		assert.Equal(t, []any{1, 2}, collect(it))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var m map[string]int
+		it := anyiter.Zip(anyiter.NewIter([]int{1}), anyiter.NewIter(m))
+		assert.Nil(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNilContainer))
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		it := anyiter.FlatMap(anyiter.NewIter([][]int{{1, 2}, nil, {3}}), func(it anyiter.Iter) anyiter.Iter {
+			v, _ := it.Value().Interface()
+			return anyiter.NewIter(v)
+		})
+		assert.Equal(t, []any{1, 2, 3}, collect(it))
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		it := anyiter.FlatMap(anyiter.NewIter([]int{1, 2}), func(anyiter.Iter) anyiter.Iter { return nil })
+		assert.Nil(t, collect(it))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		it := anyiter.FlatMap(anyiter.NewIter([]any{[]int{1}, 2, []int{3}}), func(it anyiter.Iter) anyiter.Iter {
+			v, _ := it.Value().Interface()
+			return anyiter.NewIter(v)
+		})
+		assert.Equal(t, []any{1}, collect(it))
+		assert.True(t, errors.Is(it.Err(), anyiter.ErrNotIterable))
+	})
+}
+
+func TestEnumerate(t *testing.T) {
+	it := anyiter.Enumerate(anyiter.Filter(anyiter.NewIter([]string{"a", "b", "c"}), func(it anyiter.Iter) bool {
+		return it.Index() != 1
+	}))
+
+	var keys []any
+	for next := it.Next(); next != nil; next = next.Next() {
+		k, _ := next.Key().Interface()
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []any{0, 1}, keys)
+	assert.Equal(t, []any{"a", "c"}, collect(it))
+}
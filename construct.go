@@ -0,0 +1,200 @@
+package anyiter
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// The constructors below are recreations of reflect's type constructors that take and return SafeTypes, and error
+// instead of panicking on input reflect rejects. Their errors are a *TypeError, or a *KindError for a parameter of
+// the wrong Kind.
+
+// maxFuncParams is the most parameters and results together that a func type made by FuncOf can have.
+const maxFuncParams = 128
+
+// maxChanElemSize is the size in bytes that the element type of a channel type must be smaller than.
+const maxChanElemSize = 1 << 16
+
+// SliceOf returns the slice type with element type elem.
+// It errors if elem is nil.
+func SliceOf(elem SafeType) (SafeType, error) {
+	e := reflectTypeOf(elem)
+	if e == nil {
+		return nil, invalidType("SliceOf", "element type is nil")
+	}
+	return NewSafeType(reflect.SliceOf(e)), nil
+}
+
+// MapOf returns the map type with the given key and element types.
+// It errors if either type is nil, or if key is not comparable and so not a valid map key type.
+func MapOf(key, elem SafeType) (SafeType, error) {
+	k, e := reflectTypeOf(key), reflectTypeOf(elem)
+	switch {
+	case k == nil:
+		return nil, invalidType("MapOf", "key type is nil")
+	case e == nil:
+		return nil, invalidType("MapOf", "element type is nil")
+	case !k.Comparable():
+		return nil, invalidType("MapOf", "key type %s is not comparable", k)
+	}
+	return NewSafeType(reflect.MapOf(k, e)), nil
+}
+
+// ChanOf returns the channel type with the given direction and element type.
+// It errors if elem is nil, if dir is not SendDir, RecvDir or BothDir, or if elem is too large to be sent on a
+// channel.
+func ChanOf(dir reflect.ChanDir, elem SafeType) (SafeType, error) {
+	e := reflectTypeOf(elem)
+	switch {
+	case e == nil:
+		return nil, invalidType("ChanOf", "element type is nil")
+	case dir != reflect.SendDir && dir != reflect.RecvDir && dir != reflect.BothDir:
+		return nil, invalidType("ChanOf", "invalid channel direction %d", dir)
+	case e.Size() >= maxChanElemSize:
+		return nil, invalidType("ChanOf", "element type %s is too large for a channel: size %d, want less than %d", e,
+			e.Size(), maxChanElemSize)
+	}
+	return NewSafeType(reflect.ChanOf(dir, e)), nil
+}
+
+// FuncOf returns the func type with the given parameter and result types. If variadic is true, the func type is
+// variadic, and its last parameter type must be a slice type.
+// It errors if any type is nil, if a variadic func type has no slice last parameter, or if there are more than 128
+// parameters and results together.
+func FuncOf(in, out []SafeType, variadic bool) (SafeType, error) {
+	ins, err := reflectTypesOf("FuncOf", "parameter", in)
+	if err != nil {
+		return nil, err
+	}
+	outs, err := reflectTypesOf("FuncOf", "result", out)
+	if err != nil {
+		return nil, err
+	}
+
+	if variadic {
+		if len(ins) == 0 {
+			return nil, invalidType("FuncOf", "variadic func type has no parameters")
+		}
+		if err := checkKind("FuncOf", ins[len(ins)-1].Kind(), reflect.Slice); err != nil {
+			return nil, err
+		}
+	}
+	if n := len(ins) + len(outs); n > maxFuncParams {
+		return nil, invalidType("FuncOf", "too many parameters and results: got %d, want at most %d", n,
+			maxFuncParams)
+	}
+	return NewSafeType(reflect.FuncOf(ins, outs, variadic)), nil
+}
+
+// ArrayOf returns the array type with the given length and element type.
+// It errors if elem is nil, if length is negative, or if the array type would be larger than the address space.
+func ArrayOf(length int, elem SafeType) (SafeType, error) {
+	e := reflectTypeOf(elem)
+	switch {
+	case e == nil:
+		return nil, invalidType("ArrayOf", "element type is nil")
+	case length < 0:
+		return nil, invalidType("ArrayOf", "negative array length %d", length)
+	case e.Size() > 0 && uintptr(length) > ^uintptr(0)/e.Size():
+		return nil, invalidType("ArrayOf", "array of %d %s would exceed the address space", length, e)
+	}
+	return NewSafeType(reflect.ArrayOf(length, e)), nil
+}
+
+// PointerTo returns the pointer type with element type elem.
+// It errors if elem is nil.
+func PointerTo(elem SafeType) (SafeType, error) {
+	e := reflectTypeOf(elem)
+	if e == nil {
+		return nil, invalidType("PointerTo", "element type is nil")
+	}
+	return NewSafeType(reflect.PointerTo(e)), nil
+}
+
+// StructOf returns the struct type with the given fields. As with reflect.StructOf, the Offset and Index of each
+// field are ignored and computed anew.
+// It errors if a field has no type, a name that isn't a valid Go identifier, or the same name as another field other
+// than "_". It also errors if an unexported field has no PkgPath, an embedded field has one, fields have different
+// PkgPaths, or an embedded field is a pointer to a pointer or interface. reflect can't make some struct types with
+// embedded fields that have methods; StructOf errors for those too.
+func StructOf(fields []reflect.StructField) (SafeType, error) {
+	names := make(map[string]bool, len(fields))
+	pkgPath := ""
+	for i, field := range fields {
+		switch {
+		case field.Name == "":
+			return nil, invalidType("StructOf", "field %d has no name", i)
+		case !isIdentifier(field.Name):
+			return nil, invalidType("StructOf", "field %d has invalid name %q", i, field.Name)
+		case field.Type == nil:
+			return nil, invalidType("StructOf", "field %d has no type", i)
+		case field.Anonymous && field.PkgPath != "":
+			return nil, invalidType("StructOf", "field %d is embedded but has PkgPath %q", i, field.PkgPath)
+		case field.PkgPath == "" && !token.IsExported(field.Name):
+			return nil, invalidType("StructOf", "field %d is unexported but has no PkgPath", i)
+		case field.PkgPath != "" && pkgPath != "" && field.PkgPath != pkgPath:
+			return nil, invalidType("StructOf", "field %d has PkgPath %q, but other fields have PkgPath %q", i,
+				field.PkgPath, pkgPath)
+		case names[field.Name] && field.Name != "_":
+			return nil, invalidType("StructOf", "field %d has duplicate name %s", i, field.Name)
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Ptr {
+			if k := field.Type.Elem().Kind(); k == reflect.Ptr || k == reflect.Interface {
+				return nil, invalidType("StructOf", "field %d has invalid embedded type %s", i, field.Type)
+			}
+		}
+
+		names[field.Name] = true
+		if field.PkgPath != "" {
+			pkgPath = field.PkgPath
+		}
+	}
+
+	// reflect's remaining restrictions, on embedded fields with methods and on the struct size, are left for it to
+	// report.
+	var t reflect.Type
+	if err := catchPanic(func() { t = reflect.StructOf(fields) }); err != nil {
+		reason := strings.TrimPrefix(strings.TrimPrefix(err.Error(), "reflect.StructOf: "), "reflect: ")
+		return nil, invalidType("StructOf", "%s", reason)
+	}
+	return NewSafeType(t), nil
+}
+
+// invalidType returns a *TypeError for the operation op, with the reason formatted from format and args.
+func invalidType(op, format string, args ...any) error {
+	return &TypeError{Op: op, Reason: fmt.Sprintf(format, args...)}
+}
+
+// reflectTypeOf returns the reflect.Type t wraps, or nil if t is nil.
+func reflectTypeOf(t SafeType) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	return t.ReflectType()
+}
+
+// reflectTypesOf returns the reflect.Types ts wrap. It returns a *TypeError for the operation op if one is nil, naming
+// it as the i'th what.
+func reflectTypesOf(op, what string, ts []SafeType) ([]reflect.Type, error) {
+	types := make([]reflect.Type, len(ts))
+	for i, t := range ts {
+		if types[i] = reflectTypeOf(t); types[i] == nil {
+			return nil, invalidType(op, "%s %d type is nil", what, i)
+		}
+	}
+	return types, nil
+}
+
+// isIdentifier reports whether name is a valid Go identifier: a letter or underscore followed by letters, underscores
+// and digits.
+func isIdentifier(name string) bool {
+	for i, c := range name {
+		if !unicode.IsLetter(c) && c != '_' && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,183 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+// assertTypeError asserts that err is a *TypeError for the operation op with the given reason.
+func assertTypeError(t *testing.T, err error, op, reason string) {
+	t.Helper()
+
+	var typeErr *anyiter.TypeError
+	if assert.True(t, errors.As(err, &typeErr), "error is not a *TypeError: %v", err) {
+		assert.Equal(t, op, typeErr.Op)
+		assert.Equal(t, reason, typeErr.Reason)
+	}
+	assert.True(t, errors.Is(err, anyiter.ErrInvalidType))
+}
+
+func TestSliceOf(t *testing.T) {
+	typ, err := anyiter.SliceOf(anyiter.TypeOf[int]())
+	assert.Nil(t, err)
+	assert.True(t, typ == anyiter.TypeOf[[]int]())
+
+	_, err = anyiter.SliceOf(nil)
+	assertTypeError(t, err, "SliceOf", "element type is nil")
+}
+
+func TestMapOf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		typ, err := anyiter.MapOf(anyiter.TypeOf[string](), anyiter.TypeOf[int]())
+		assert.Nil(t, err)
+		assert.True(t, typ == anyiter.TypeOf[map[string]int]())
+	})
+
+	t.Run("not comparable", func(t *testing.T) {
+		_, err := anyiter.MapOf(anyiter.TypeOf[[]int](), anyiter.TypeOf[int]())
+		assertTypeError(t, err, "MapOf", "key type []int is not comparable")
+		assert.EqualError(t, err, "MapOf: key type []int is not comparable")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := anyiter.MapOf(nil, anyiter.TypeOf[int]())
+		assertTypeError(t, err, "MapOf", "key type is nil")
+		_, err = anyiter.MapOf(anyiter.TypeOf[int](), nil)
+		assertTypeError(t, err, "MapOf", "element type is nil")
+	})
+}
+
+func TestChanOf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		typ, err := anyiter.ChanOf(reflect.RecvDir, anyiter.TypeOf[int]())
+		assert.Nil(t, err)
+		assert.True(t, typ == anyiter.TypeOf[<-chan int]())
+	})
+
+	t.Run("invalid direction", func(t *testing.T) {
+		_, err := anyiter.ChanOf(0, anyiter.TypeOf[int]())
+		assertTypeError(t, err, "ChanOf", "invalid channel direction 0")
+	})
+
+	t.Run("element too large", func(t *testing.T) {
+		_, err := anyiter.ChanOf(reflect.BothDir, anyiter.TypeOf[[1 << 16]byte]())
+		assertTypeError(t, err, "ChanOf",
+			"element type [65536]uint8 is too large for a channel: size 65536, want less than 65536")
+	})
+}
+
+func TestFuncOf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		in := []anyiter.SafeType{anyiter.TypeOf[string](), anyiter.TypeOf[[]int]()}
+		out := []anyiter.SafeType{anyiter.TypeOf[error]()}
+		typ, err := anyiter.FuncOf(in, out, true)
+		assert.Nil(t, err)
+		assert.True(t, typ == anyiter.TypeOf[func(string, ...int) error]())
+	})
+
+	t.Run("variadic", func(t *testing.T) {
+		_, err := anyiter.FuncOf(nil, nil, true)
+		assertTypeError(t, err, "FuncOf", "variadic func type has no parameters")
+		_, err = anyiter.FuncOf([]anyiter.SafeType{anyiter.TypeOf[int]()}, nil, true)
+		assertKindError(t, err, "FuncOf", reflect.Slice)
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		in := make([]anyiter.SafeType, 129)
+		for i := range in {
+			in[i] = anyiter.TypeOf[int]()
+		}
+		_, err := anyiter.FuncOf(in, nil, false)
+		assertTypeError(t, err, "FuncOf", "too many parameters and results: got 129, want at most 128")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := anyiter.FuncOf([]anyiter.SafeType{anyiter.TypeOf[int](), nil}, nil, false)
+		assertTypeError(t, err, "FuncOf", "parameter 1 type is nil")
+		_, err = anyiter.FuncOf(nil, []anyiter.SafeType{nil}, false)
+		assertTypeError(t, err, "FuncOf", "result 0 type is nil")
+	})
+}
+
+func TestArrayOf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		typ, err := anyiter.ArrayOf(3, anyiter.TypeOf[int]())
+		assert.Nil(t, err)
+		assert.True(t, typ == anyiter.TypeOf[[3]int]())
+	})
+
+	t.Run("negative length", func(t *testing.T) {
+		_, err := anyiter.ArrayOf(-1, anyiter.TypeOf[int]())
+		assertTypeError(t, err, "ArrayOf", "negative array length -1")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := anyiter.ArrayOf(1<<62, anyiter.TypeOf[[16]byte]())
+		assertTypeError(t, err, "ArrayOf", "array of 4611686018427387904 [16]uint8 would exceed the address space")
+	})
+}
+
+func TestPointerTo(t *testing.T) {
+	typ, err := anyiter.PointerTo(anyiter.TypeOf[testStruct]())
+	assert.Nil(t, err)
+	assert.True(t, typ == anyiter.TypeOf[*testStruct]())
+
+	_, err = anyiter.PointerTo(nil)
+	assertTypeError(t, err, "PointerTo", "element type is nil")
+}
+
+func TestStructOf(t *testing.T) {
+	field := func(name string, typ reflect.Type) reflect.StructField {
+		return reflect.StructField{Name: name, Type: typ}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		typ, err := anyiter.StructOf([]reflect.StructField{
+			field("Name", reflect.TypeOf("")),
+			{Name: "age", Type: reflect.TypeOf(0), PkgPath: "example.com/schema", Tag: `json:"age"`},
+			{Name: "_", Type: reflect.TypeOf(0), PkgPath: "example.com/schema"},
+			{Name: "_", Type: reflect.TypeOf(0), PkgPath: "example.com/schema"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, reflect.Struct, typ.Kind())
+		assert.Equal(t, 4, typ.ReflectType().NumField())
+	})
+
+	tests := []struct {
+		name   string
+		fields []reflect.StructField
+		err    string
+	}{
+		{"no name", []reflect.StructField{field("", reflect.TypeOf(0))}, "field 0 has no name"},
+		{"invalid name", []reflect.StructField{field("A", reflect.TypeOf(0)), field("1B", reflect.TypeOf(0))},
+			`field 1 has invalid name "1B"`},
+		{"no type", []reflect.StructField{field("A", nil)}, "field 0 has no type"},
+		{"duplicate", []reflect.StructField{field("A", reflect.TypeOf(0)), field("A", reflect.TypeOf(""))},
+			"field 1 has duplicate name A"},
+		{"unexported", []reflect.StructField{field("a", reflect.TypeOf(0))},
+			"field 0 is unexported but has no PkgPath"},
+		{"embedded with PkgPath", []reflect.StructField{
+			{Name: "TestStruct", Type: reflect.TypeOf(0), Anonymous: true, PkgPath: "example.com/schema"},
+		}, `field 0 is embedded but has PkgPath "example.com/schema"`},
+		{"different PkgPaths", []reflect.StructField{
+			{Name: "a", Type: reflect.TypeOf(0), PkgPath: "example.com/a"},
+			{Name: "b", Type: reflect.TypeOf(0), PkgPath: "example.com/b"},
+		}, `field 1 has PkgPath "example.com/b", but other fields have PkgPath "example.com/a"`},
+		{"embedded pointer to pointer", []reflect.StructField{
+			{Name: "P", Type: reflect.TypeOf((**int)(nil)), Anonymous: true},
+		}, "field 0 has invalid embedded type **int"},
+		{"embedded type with methods", []reflect.StructField{
+			field("A", reflect.TypeOf(0)),
+			{Name: "Value", Type: reflect.TypeOf(reflect.Value{}), Anonymous: true},
+		}, "embedded type with methods not implemented if type is not first field"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := anyiter.StructOf(test.fields)
+			assertTypeError(t, err, "StructOf", test.err)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package anyiter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrKindMismatch is wrapped by the errors of operations applied to a type or value of the wrong Kind. Most of
+	// them are a *KindError.
+	ErrKindMismatch = errors.New("kind mismatch")
+
+	// ErrIndexOutOfRange is wrapped by the errors of operations given an index outside the bounds of a type or
+	// value. Most of them are an *IndexError.
+	ErrIndexOutOfRange = errors.New("index out of range")
+
+	// ErrNotFound is wrapped by the errors of lookups that found nothing, like calling a method that doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrNotIterable is wrapped by an IterError when a value has no elements to iterate over.
+	ErrNotIterable = errors.New("value is not iterable")
+
+	// ErrNilContainer is wrapped by an IterError when a map, channel, pointer or interface to iterate over is nil.
+	ErrNilContainer = errors.New("value is nil")
+
+	// ErrInvalidType is wrapped by the errors of type constructors given input that can't make a valid type. Most of
+	// them are a *TypeError.
+	ErrInvalidType = errors.New("invalid type")
+
+	// ErrNilPointer is wrapped by the errors of operations that need to follow a pointer that is nil. Most of them
+	// are a *NilPointerError.
+	ErrNilPointer = errors.New("nil pointer")
+
+	// ErrMalformedTag is wrapped by the errors of operations that parse a struct tag that doesn't follow the
+	// conventional format. Most of them are a *TagError.
+	ErrMalformedTag = errors.New("malformed struct tag")
+
+	// ErrNotSettable is returned when a value can't be set, because it isn't addressable or was obtained using an
+	// unexported field.
+	ErrNotSettable = errors.New("value is not settable")
+
+	// ErrUnexported is returned when a value was obtained using an unexported field, and so can't be used to
+	// receive from a channel or call a func.
+	ErrUnexported = errors.New("value was obtained using an unexported field")
+)
+
+// KindError is returned when an operation is applied to a type or value of the wrong Kind. It wraps ErrKindMismatch.
+type KindError struct {
+	// Op is the operation, such as "SafeType.In".
+	Op string
+	// Expected are the kinds the operation applies to.
+	Expected []reflect.Kind
+	// Actual is the kind it was applied to.
+	Actual reflect.Kind
+}
+
+func (e *KindError) Error() string {
+	expected := make([]string, len(e.Expected))
+	for i, k := range e.Expected {
+		expected[i] = kindName(k)
+	}
+
+	var list string
+	if n := len(expected); n > 1 {
+		list = strings.Join(expected[:n-1], ", ") + " or " + expected[n-1]
+	} else {
+		list = strings.Join(expected, "")
+	}
+	return fmt.Sprintf("%s: kind %s is not %s", e.Op, e.Actual, list)
+}
+
+func (e *KindError) Unwrap() error {
+	return ErrKindMismatch
+}
+
+// kindName returns the name of the reflect constant for k, like Int64 for reflect.Int64.
+func kindName(k reflect.Kind) string {
+	switch k {
+	case reflect.Ptr:
+		return "Ptr"
+	case reflect.UnsafePointer:
+		return "UnsafePointer"
+	}
+	s := k.String()
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// IndexError is returned when an operation is given an index outside the bounds of a type or value. It wraps
+// ErrIndexOutOfRange.
+type IndexError struct {
+	// Op is the operation, such as "SafeValue.Index".
+	Op string
+	// Index is the index that is out of range.
+	Index int
+	// Len is the number of valid indexes, or for slicing the largest valid bound.
+	Len int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("%s: index out of range [%d] with length %d", e.Op, e.Index, e.Len)
+}
+
+func (e *IndexError) Unwrap() error {
+	return ErrIndexOutOfRange
+}
+
+// TypeError is returned when a type constructor, like MapOf, is given input that can't make a valid type. It wraps
+// ErrInvalidType.
+type TypeError struct {
+	// Op is the operation, such as "MapOf".
+	Op string
+	// Reason describes what is invalid.
+	Reason string
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Op, e.Reason)
+}
+
+func (e *TypeError) Unwrap() error {
+	return ErrInvalidType
+}
+
+// NilPointerError is returned when a field lookup needs to follow a nil pointer to a struct, like an embedded *T,
+// to reach a field. It wraps ErrNilPointer.
+type NilPointerError struct {
+	// Op is the operation, such as "SafeValue.FieldByIndex".
+	Op string
+	// Field is the pointer field that is nil.
+	Field reflect.StructField
+	// Index is the index path of Field, from the struct the lookup started at.
+	Index []int
+}
+
+func (e *NilPointerError) Error() string {
+	return fmt.Sprintf("%s: field %s at index %v is a nil %s", e.Op, e.Field.Name, e.Index, e.Field.Type)
+}
+
+func (e *NilPointerError) Unwrap() error {
+	return ErrNilPointer
+}
+
+// checkKind returns a *KindError for the operation op if actual is not one of kinds.
+func checkKind(op string, actual reflect.Kind, kinds ...reflect.Kind) error {
+	for _, k := range kinds {
+		if actual == k {
+			return nil
+		}
+	}
+	return &KindError{Op: op, Expected: kinds, Actual: actual}
+}
+
+// checkIndex returns an *IndexError if i is not in the range [0, n).
+func checkIndex(op string, i, n int) error {
+	if i < 0 || i >= n {
+		return &IndexError{Op: op, Index: i, Len: n}
+	}
+	return nil
+}
+
+// checkBounds returns an *IndexError for the first of the slice bounds that isn't in order between 0 and limit.
+func checkBounds(op string, limit int, bounds ...int) error {
+	low := 0
+	for _, b := range bounds {
+		if b < low || b > limit {
+			return &IndexError{Op: op, Index: b, Len: limit}
+		}
+		low = b
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+// assertKindError asserts that err is a *KindError for the operation op and the expected kinds.
+func assertKindError(t *testing.T, err error, op string, expected ...reflect.Kind) {
+	t.Helper()
+
+	var kindErr *anyiter.KindError
+	if assert.True(t, errors.As(err, &kindErr), "error is not a *KindError: %v", err) {
+		assert.Equal(t, op, kindErr.Op)
+		assert.Equal(t, expected, kindErr.Expected)
+	}
+	assert.True(t, errors.Is(err, anyiter.ErrKindMismatch))
+}
+
+// assertIndexError asserts that err is an *IndexError for the operation op.
+func assertIndexError(t *testing.T, err error, op string) {
+	t.Helper()
+
+	var indexErr *anyiter.IndexError
+	if assert.True(t, errors.As(err, &indexErr), "error is not an *IndexError: %v", err) {
+		assert.Equal(t, op, indexErr.Op)
+	}
+	assert.True(t, errors.Is(err, anyiter.ErrIndexOutOfRange))
+}
+
+func TestKindError(t *testing.T) {
+	_, err := anyiter.NewSafeValue(reflect.ValueOf("a")).Int()
+
+	var kindErr *anyiter.KindError
+	assert.True(t, errors.As(err, &kindErr))
+	assert.Equal(t, &anyiter.KindError{
+		Op:       "SafeValue.Int",
+		Expected: []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64},
+		Actual:   reflect.String,
+	}, kindErr)
+	assert.Equal(t, "SafeValue.Int: kind string is not Int, Int8, Int16, Int32 or Int64", err.Error())
+	assert.True(t, errors.Is(err, anyiter.ErrKindMismatch))
+
+	_, err = anyiter.NewSafeType(reflect.TypeOf(1)).Key()
+	assert.Equal(t, "SafeType.Key: kind int is not Map", err.Error())
+
+	_, err = anyiter.NewSafeValue(reflect.ValueOf(1)).Elem()
+	assert.Equal(t, "SafeValue.Elem: kind int is not Interface or Ptr", err.Error())
+}
+
+func TestIndexError(t *testing.T) {
+	_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2})).Index(2)
+
+	var indexErr *anyiter.IndexError
+	assert.True(t, errors.As(err, &indexErr))
+	assert.Equal(t, &anyiter.IndexError{Op: "SafeValue.Index", Index: 2, Len: 2}, indexErr)
+	assert.Equal(t, "SafeValue.Index: index out of range [2] with length 2", err.Error())
+	assert.True(t, errors.Is(err, anyiter.ErrIndexOutOfRange))
+
+	t.Run("slice bounds", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2})).Slice(2, 1)
+		assert.True(t, errors.As(err, &indexErr))
+		assert.Equal(t, &anyiter.IndexError{Op: "SafeValue.Slice", Index: 1, Len: 2}, indexErr)
+
+		_, err = anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2})).Slice3(0, 1, 3)
+		assert.True(t, errors.As(err, &indexErr))
+		assert.Equal(t, &anyiter.IndexError{Op: "SafeValue.Slice3", Index: 3, Len: 2}, indexErr)
+	})
+}
+
+func TestErrNotFound(t *testing.T) {
+	_, err := anyiter.CallMethod(testStruct{}, "Missing")
+	assert.True(t, errors.Is(err, anyiter.ErrNotFound))
+}
@@ -0,0 +1,244 @@
+package anyiter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ImplementsError explains why a type does not implement an interface. It is returned by ExplainImplements.
+type ImplementsError struct {
+	// Type is the type that doesn't implement Interface.
+	Type SafeType
+	// Interface is the interface type.
+	Interface SafeType
+
+	// Missing are the methods of Interface that neither Type nor a pointer to Type has.
+	Missing []SafeMethod
+	// Mismatched are the methods of Interface that Type has with a different signature.
+	Mismatched []MethodMismatch
+	// PointerOnly are the methods of Interface that only a pointer to Type has, because they have a pointer receiver.
+	PointerOnly []SafeMethod
+	// Unexported are the unexported methods of Interface that couldn't be checked, because reflect can't look up the
+	// unexported methods of a non-interface type. Any of them may be missing, mismatched or pointer only.
+	Unexported []SafeMethod
+}
+
+// MethodMismatch is a method whose signature differs from the interface method of the same name.
+type MethodMismatch struct {
+	// Name is the name of the method.
+	Name string
+	// Want is the signature of the interface method, and Got that of the type's method. Neither has a receiver.
+	Want SafeType
+	Got  SafeType
+}
+
+func (e *ImplementsError) Error() string {
+	var reasons []string
+	for _, m := range e.Missing {
+		reasons = append(reasons, fmt.Sprintf("missing method %s", m.Name()))
+	}
+	for _, m := range e.Mismatched {
+		reasons = append(reasons, fmt.Sprintf("method %s has signature %s, want %s", m.Name, m.Got, m.Want))
+	}
+	for _, m := range e.PointerOnly {
+		reasons = append(reasons, fmt.Sprintf("method %s has a pointer receiver", m.Name()))
+	}
+	for _, m := range e.Unexported {
+		reasons = append(reasons, fmt.Sprintf("unexported method %s can't be checked", m.Name()))
+	}
+	return fmt.Sprintf("%s does not implement %s: %s", e.Type, e.Interface, strings.Join(reasons, "; "))
+}
+
+// ExplainImplements returns an *ImplementsError explaining why t does not implement the interface type u, or nil if
+// it does. It errors if u is not an interface type.
+//
+// The unexported methods of u can only be checked individually if t is an interface type too. Otherwise they are
+// reported as Unexported, unless the other methods rule out all but a pointer receiver.
+func ExplainImplements(t, u SafeType) error {
+	if err := checkKind("ExplainImplements", u.Kind(), reflect.Interface); err != nil {
+		return err
+	}
+	if t.Implements(u) {
+		return nil
+	}
+
+	rt := t.ReflectType()
+	e := &ImplementsError{Type: t, Interface: u}
+	for i := 0; i < u.NumMethod(); i++ {
+		want, _ := u.Method(i)
+		if want.PkgPath() != "" && rt.Kind() != reflect.Interface {
+			e.Unexported = append(e.Unexported, want)
+			continue
+		}
+
+		m, ok := rt.MethodByName(want.Name())
+		pointerOnly := false
+		if !ok && rt.Kind() != reflect.Interface {
+			m, ok = reflect.PointerTo(rt).MethodByName(want.Name())
+			pointerOnly = ok
+		}
+
+		switch got := signature(m, rt.Kind() == reflect.Interface); {
+		case !ok:
+			e.Missing = append(e.Missing, want)
+		case got != want.Type().ReflectType():
+			mismatch := MethodMismatch{Name: want.Name(), Want: want.Type(), Got: NewSafeType(got)}
+			e.Mismatched = append(e.Mismatched, mismatch)
+		case pointerOnly:
+			e.PointerOnly = append(e.PointerOnly, NewSafeMethod(m))
+		}
+	}
+
+	// If every other method checks out and a pointer to t implements u, a single unexported method must be the one
+	// with a pointer receiver.
+	if len(e.Unexported) == 1 && len(e.Missing)+len(e.Mismatched)+len(e.PointerOnly) == 0 &&
+		reflect.PointerTo(rt).Implements(u.ReflectType()) {
+		e.PointerOnly, e.Unexported = e.Unexported, nil
+	}
+	return e
+}
+
+// signature returns the signature of the method m without its receiver. The methods of an interface type have no
+// receiver to begin with.
+func signature(m reflect.Method, ofInterface bool) reflect.Type {
+	if m.Type == nil || ofInterface {
+		return m.Type
+	}
+
+	ins := make([]reflect.Type, m.Type.NumIn()-1)
+	for i := range ins {
+		ins[i] = m.Type.In(i + 1)
+	}
+	outs := make([]reflect.Type, m.Type.NumOut())
+	for i := range outs {
+		outs[i] = m.Type.Out(i)
+	}
+	return reflect.FuncOf(ins, outs, m.Type.IsVariadic())
+}
+
+// AssignableError explains why a type is not assignable to another. It is returned by ExplainAssignable.
+type AssignableError struct {
+	// Type is the type that isn't assignable to Target.
+	Type   SafeType
+	Target SafeType
+	// Reason describes why.
+	Reason string
+	// Implements explains why Type doesn't implement Target, if Target is an interface type.
+	Implements *ImplementsError
+}
+
+func (e *AssignableError) Error() string {
+	return fmt.Sprintf("%s is not assignable to %s: %s", e.Type, e.Target, e.Reason)
+}
+
+func (e *AssignableError) Unwrap() error {
+	if e.Implements == nil {
+		return nil
+	}
+	return e.Implements
+}
+
+// ExplainAssignable returns an *AssignableError explaining why a value of type t is not assignable to type u, or nil
+// if it is.
+func ExplainAssignable(t, u SafeType) error {
+	if t.AssignableTo(u) {
+		return nil
+	}
+
+	e := &AssignableError{Type: t, Target: u}
+	rt, ru := t.ReflectType(), u.ReflectType()
+	switch {
+	case ru.Kind() == reflect.Interface:
+		e.Implements = ExplainImplements(t, u).(*ImplementsError)
+		e.Reason = "it does not implement the interface"
+	case rt.Kind() == reflect.Chan && ru.Kind() == reflect.Chan && rt.Elem() == ru.Elem() &&
+		rt.ChanDir() != reflect.BothDir:
+		e.Reason = "only a bidirectional channel can be assigned to a channel type of another direction"
+	case rt.ConvertibleTo(ru):
+		e.Reason = "an explicit conversion is needed"
+	default:
+		e.Reason = difference(rt, ru)
+	}
+	return e
+}
+
+// ConvertibleError explains why a type is not convertible to another. It is returned by ExplainConvertible.
+type ConvertibleError struct {
+	// Type is the type that isn't convertible to Target.
+	Type   SafeType
+	Target SafeType
+	// Reason describes why.
+	Reason string
+	// Implements explains why Type doesn't implement Target, if Target is an interface type.
+	Implements *ImplementsError
+}
+
+func (e *ConvertibleError) Error() string {
+	return fmt.Sprintf("%s is not convertible to %s: %s", e.Type, e.Target, e.Reason)
+}
+
+func (e *ConvertibleError) Unwrap() error {
+	if e.Implements == nil {
+		return nil
+	}
+	return e.Implements
+}
+
+// ExplainConvertible returns a *ConvertibleError explaining why a value of type t is not convertible to type u, or
+// nil if it is.
+func ExplainConvertible(t, u SafeType) error {
+	if t.ConvertibleTo(u) {
+		return nil
+	}
+
+	e := &ConvertibleError{Type: t, Target: u}
+	rt, ru := t.ReflectType(), u.ReflectType()
+	switch {
+	case ru.Kind() == reflect.Interface:
+		e.Implements = ExplainImplements(t, u).(*ImplementsError)
+		e.Reason = "it does not implement the interface"
+	case rt.Kind() == reflect.String && ru.Kind() == reflect.Slice:
+		e.Reason = "a string can only be converted to a slice of bytes or runes"
+	case rt.Kind() == reflect.Slice && ru.Kind() == reflect.String:
+		e.Reason = "only a slice of bytes or runes can be converted to a string"
+	case rt.Kind() == reflect.Slice && (ru.Kind() == reflect.Array ||
+		ru.Kind() == reflect.Ptr && ru.Elem().Kind() == reflect.Array):
+		e.Reason = "a slice can only be converted to an array or array pointer of the same element type"
+	default:
+		e.Reason = difference(rt, ru)
+	}
+	return e
+}
+
+// difference describes how the types t and u differ.
+func difference(t, u reflect.Type) string {
+	if t.Kind() != u.Kind() {
+		return fmt.Sprintf("kind %s differs from kind %s", t.Kind(), u.Kind())
+	}
+
+	switch t.Kind() {
+	case reflect.Array:
+		if t.Len() != u.Len() {
+			return fmt.Sprintf("array lengths %d and %d differ", t.Len(), u.Len())
+		}
+		return fmt.Sprintf("element types %s and %s differ", t.Elem(), u.Elem())
+	case reflect.Chan:
+		if t.ChanDir() != u.ChanDir() {
+			return fmt.Sprintf("channel directions %s and %s differ", t.ChanDir(), u.ChanDir())
+		}
+		return fmt.Sprintf("element types %s and %s differ", t.Elem(), u.Elem())
+	case reflect.Map:
+		if t.Key() != u.Key() {
+			return fmt.Sprintf("key types %s and %s differ", t.Key(), u.Key())
+		}
+		return fmt.Sprintf("element types %s and %s differ", t.Elem(), u.Elem())
+	case reflect.Ptr, reflect.Slice:
+		return fmt.Sprintf("element types %s and %s differ", t.Elem(), u.Elem())
+	case reflect.Func:
+		return "signatures differ"
+	case reflect.Struct:
+		return "fields differ"
+	}
+	return "underlying types differ"
+}
@@ -0,0 +1,166 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type testSetter interface {
+	GetSomeField() int
+	SetID(int)
+	Close() error
+}
+
+type testMismatched struct{}
+
+func (testMismatched) GetSomeField() string { return "" }
+
+type testHidden interface {
+	hidden()
+	Shown() int
+}
+
+type testValueHidden struct{}
+
+func (testValueHidden) hidden() {}
+
+type testPointerHidden struct{}
+
+func (*testPointerHidden) hidden()   {}
+func (testPointerHidden) Shown() int { return 0 }
+
+type testNamedInt int
+type otherNamedInt int
+
+func TestExplainImplements(t *testing.T) {
+	setter := anyiter.TypeOf[testSetter]()
+
+	t.Run("implements", func(t *testing.T) {
+		iface := anyiter.TypeOf[testInterface]()
+		assert.Nil(t, anyiter.ExplainImplements(anyiter.NewSafeType(reflect.TypeOf(testStruct{})), iface))
+	})
+
+	t.Run("not an interface", func(t *testing.T) {
+		typ := anyiter.NewSafeType(reflect.TypeOf(1))
+		assertKindError(t, anyiter.ExplainImplements(typ, typ), "ExplainImplements", reflect.Interface)
+	})
+
+	t.Run("explanation", func(t *testing.T) {
+		type partialSetter struct {
+			testBase
+			testMismatched
+		}
+		err := anyiter.ExplainImplements(anyiter.NewSafeType(reflect.TypeOf(partialSetter{})), setter)
+
+		var implErr *anyiter.ImplementsError
+		assert.True(t, errors.As(err, &implErr))
+		assert.Len(t, implErr.Missing, 1)
+		assert.Equal(t, "Close", implErr.Missing[0].Name())
+		assert.Len(t, implErr.Mismatched, 1)
+		assert.Equal(t, "GetSomeField", implErr.Mismatched[0].Name)
+		assert.Equal(t, "func() int", implErr.Mismatched[0].Want.String())
+		assert.Equal(t, "func() string", implErr.Mismatched[0].Got.String())
+		assert.Len(t, implErr.PointerOnly, 1)
+		assert.Equal(t, "SetID", implErr.PointerOnly[0].Name())
+		assert.Equal(t, "anyiter_test.partialSetter does not implement anyiter_test.testSetter: missing method Close; "+
+			"method GetSomeField has signature func() string, want func() int; method SetID has a pointer receiver",
+			err.Error())
+	})
+
+	t.Run("unexported method", func(t *testing.T) {
+		hidden := anyiter.TypeOf[testHidden]()
+		err := anyiter.ExplainImplements(anyiter.TypeOf[testValueHidden](), hidden)
+		var implErr *anyiter.ImplementsError
+		assert.True(t, errors.As(err, &implErr))
+		assert.Len(t, implErr.Missing, 1)
+		assert.Len(t, implErr.Unexported, 1)
+		assert.Equal(t, "anyiter_test.testValueHidden does not implement anyiter_test.testHidden: "+
+			"missing method Shown; unexported method hidden can't be checked", err.Error())
+
+		err = anyiter.ExplainImplements(anyiter.TypeOf[testPointerHidden](), hidden)
+		assert.True(t, errors.As(err, &implErr))
+		assert.Empty(t, implErr.Missing)
+		assert.Empty(t, implErr.Unexported)
+		assert.Equal(t, "anyiter_test.testPointerHidden does not implement anyiter_test.testHidden: "+
+			"method hidden has a pointer receiver", err.Error())
+
+		err = anyiter.ExplainImplements(anyiter.TypeOf[testInterface](), hidden)
+		assert.Equal(t, "anyiter_test.testInterface does not implement anyiter_test.testHidden: "+
+			"missing method Shown; missing method hidden", err.Error())
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		iface := anyiter.TypeOf[testInterface]()
+		err := anyiter.ExplainImplements(iface, setter)
+		assert.Equal(t, "anyiter_test.testInterface does not implement anyiter_test.testSetter: "+
+			"missing method Close; missing method SetID", err.Error())
+	})
+}
+
+func TestExplainAssignable(t *testing.T) {
+	explain := func(a, b any) error {
+		return anyiter.ExplainAssignable(anyiter.NewSafeType(reflect.TypeOf(a)), anyiter.NewSafeType(reflect.TypeOf(b)))
+	}
+
+	t.Run("assignable", func(t *testing.T) {
+		assert.Nil(t, explain(1, 2))
+		assert.Nil(t, explain(make(chan int), make(<-chan int)))
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		iface := anyiter.TypeOf[testInterface]()
+		err := anyiter.ExplainAssignable(anyiter.NewSafeType(reflect.TypeOf(1)), iface)
+
+		var assignErr *anyiter.AssignableError
+		assert.True(t, errors.As(err, &assignErr))
+		assert.Equal(t, "it does not implement the interface", assignErr.Reason)
+
+		var implErr *anyiter.ImplementsError
+		assert.True(t, errors.As(err, &implErr))
+		assert.Equal(t, "GetSomeField", implErr.Missing[0].Name())
+	})
+
+	t.Run("reasons", func(t *testing.T) {
+		assert.EqualError(t, explain(testNamedInt(1), otherNamedInt(1)),
+			"anyiter_test.testNamedInt is not assignable to anyiter_test.otherNamedInt: an explicit conversion is needed")
+		assert.EqualError(t, explain(make(<-chan int), make(chan int)),
+			"<-chan int is not assignable to chan int: "+
+				"only a bidirectional channel can be assigned to a channel type of another direction")
+		assert.EqualError(t, explain(true, ""), "bool is not assignable to string: kind bool differs from kind string")
+		assert.EqualError(t, explain([2]int{}, [3]int{}), "[2]int is not assignable to [3]int: array lengths 2 and 3 differ")
+		assert.EqualError(t, explain(map[string]int{}, map[int]int{}),
+			"map[string]int is not assignable to map[int]int: key types string and int differ")
+		assert.EqualError(t, explain([]int{}, []string{}),
+			"[]int is not assignable to []string: element types int and string differ")
+		assert.EqualError(t, explain(func() {}, func(int) {}), "func() is not assignable to func(int): signatures differ")
+	})
+}
+
+func TestExplainConvertible(t *testing.T) {
+	explain := func(a, b any) error {
+		return anyiter.ExplainConvertible(anyiter.NewSafeType(reflect.TypeOf(a)), anyiter.NewSafeType(reflect.TypeOf(b)))
+	}
+
+	t.Run("convertible", func(t *testing.T) {
+		assert.Nil(t, explain(testNamedInt(1), otherNamedInt(1)))
+		assert.Nil(t, explain(1, 1.5))
+		assert.Nil(t, explain("", []byte{}))
+	})
+
+	t.Run("reasons", func(t *testing.T) {
+		var convErr *anyiter.ConvertibleError
+		err := explain(true, 1)
+		assert.True(t, errors.As(err, &convErr))
+		assert.Equal(t, "kind bool differs from kind int", convErr.Reason)
+
+		assert.EqualError(t, explain("", []int{}),
+			"string is not convertible to []int: a string can only be converted to a slice of bytes or runes")
+		assert.EqualError(t, explain([]int{}, ""),
+			"[]int is not convertible to string: only a slice of bytes or runes can be converted to a string")
+		assert.EqualError(t, explain([]int{}, [2]string{}), "[]int is not convertible to [2]string: "+
+			"a slice can only be converted to an array or array pointer of the same element type")
+	})
+}
@@ -0,0 +1,83 @@
+package anyiter
+
+import (
+	"reflect"
+	"slices"
+)
+
+// FieldEntry is a field reachable from a struct type, either declared by it or promoted through embedded fields.
+type FieldEntry struct {
+	// Field is the field. Its Index is the full index path from the struct type, as for reflect.Type.FieldByIndex.
+	Field reflect.StructField
+
+	// Depth is the number of embedded fields the field is promoted through. It is 0 for a field declared by the
+	// struct type itself.
+	Depth int
+
+	// Shadowed reports whether a field of the same name at a smaller depth hides the field.
+	Shadowed bool
+
+	// Ambiguous reports whether another field of the same name is at the same depth and neither is shadowed. Go
+	// rejects selectors of ambiguous fields, and FieldByName and FieldByNameFunc don't find them.
+	Ambiguous bool
+}
+
+// Selectable reports whether the field can be selected by name from the struct type, because it is neither shadowed
+// nor ambiguous.
+func (e FieldEntry) Selectable() bool {
+	return !e.Shadowed && !e.Ambiguous
+}
+
+// allFields returns every field reachable from the struct type t, ordered by depth and then by index path.
+//
+// An embedded struct type is expanded each time it's reached, so fields reached again at a greater depth are listed
+// as shadowed, unless the type is already on the chain of embeddings it's reached through. That only happens for an
+// embedded pointer cycle, which would otherwise never end.
+func allFields(t reflect.Type) []FieldEntry {
+	var entries []FieldEntry
+
+	// chain holds the struct types the embedded field is reached through, including its own.
+	type embedding struct {
+		field reflect.StructField
+		chain []reflect.Type
+	}
+	level := []embedding{{field: reflect.StructField{Type: t}}}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []embedding
+		for _, embedded := range level {
+			st := indirectType(embedded.field.Type)
+			if slices.Contains(embedded.chain, st) {
+				continue
+			}
+			chain := append(append([]reflect.Type(nil), embedded.chain...), st)
+
+			for i := 0; i < st.NumField(); i++ {
+				field := st.Field(i)
+				field.Index = append(append([]int(nil), embedded.field.Index...), i)
+				entries = append(entries, FieldEntry{Field: field, Depth: depth})
+
+				if field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct {
+					next = append(next, embedding{field: field, chain: chain})
+				}
+			}
+		}
+		level = next
+	}
+
+	// A name is selectable at the smallest depth it's at, if only one field there has it.
+	type occurrence struct{ depth, count int }
+	names := map[string]occurrence{}
+	for _, e := range entries {
+		if o, ok := names[e.Field.Name]; !ok || e.Depth < o.depth {
+			names[e.Field.Name] = occurrence{e.Depth, 1}
+		} else if e.Depth == o.depth {
+			names[e.Field.Name] = occurrence{o.depth, o.count + 1}
+		}
+	}
+	for i, e := range entries {
+		o := names[e.Field.Name]
+		entries[i].Shadowed = e.Depth > o.depth
+		entries[i].Ambiguous = e.Depth == o.depth && o.count > 1
+	}
+	return entries
+}
@@ -0,0 +1,124 @@
+package anyiter_test
+
+import (
+	"fmt"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type testInner struct {
+	ID   int
+	Name string
+}
+
+type testOther struct {
+	ID    int
+	Owner string
+}
+
+type testEmbedding struct {
+	Name string
+	testInner
+	*testOther
+}
+
+type testLink struct {
+	*testLink
+	Value int
+}
+
+// fieldSummary returns the name, index path and flags of each entry.
+func fieldSummary(entries []anyiter.FieldEntry) []string {
+	var summary []string
+	for _, e := range entries {
+		s := fmt.Sprintf("%s %v", e.Field.Name, e.Field.Index)
+		if e.Shadowed {
+			s += " shadowed"
+		}
+		if e.Ambiguous {
+			s += " ambiguous"
+		}
+		summary = append(summary, s)
+	}
+	return summary
+}
+
+func TestSafeStructType_AllFields(t *testing.T) {
+	t.Run("flat", func(t *testing.T) {
+		st, _ := anyiter.TypeOf[testInner]().AsStruct()
+		entries := st.AllFields()
+		assert.Equal(t, []string{"ID [0]", "Name [1]"}, fieldSummary(entries))
+		assert.Equal(t, 0, entries[1].Depth)
+		assert.True(t, entries[1].Selectable())
+	})
+
+	t.Run("promoted", func(t *testing.T) {
+		st, _ := anyiter.TypeOf[testEmbedding]().AsStruct()
+		entries := st.AllFields()
+		assert.Equal(t, []string{
+			"Name [0]",
+			"testInner [1]",
+			"testOther [2]",
+			"ID [1 0] ambiguous",
+			"Name [1 1] shadowed",
+			"ID [2 0] ambiguous",
+			"Owner [2 1]",
+		}, fieldSummary(entries))
+		assert.Equal(t, 1, entries[6].Depth)
+
+		// The flags agree with reflect's selector rules.
+		typ := reflect.TypeOf(testEmbedding{})
+		for _, e := range entries {
+			field, ok := typ.FieldByName(e.Field.Name)
+			if e.Selectable() {
+				assert.True(t, ok)
+				assert.Equal(t, field.Index, e.Field.Index)
+			} else if e.Ambiguous {
+				assert.False(t, ok)
+			}
+		}
+		assert.Equal(t, typ.FieldByIndex([]int{2, 1}).Type, entries[6].Field.Type)
+	})
+
+	t.Run("ambiguous through the same type", func(t *testing.T) {
+		type left struct{ testInner }
+		type right struct{ testInner }
+		st, _ := anyiter.NewSafeType(reflect.TypeOf(struct {
+			left
+			right
+		}{})).AsStruct()
+		assert.Equal(t, []string{
+			"left [0]",
+			"right [1]",
+			"testInner [0 0] ambiguous",
+			"testInner [1 0] ambiguous",
+			"ID [0 0 0] ambiguous",
+			"Name [0 0 1] ambiguous",
+			"ID [1 0 0] ambiguous",
+			"Name [1 0 1] ambiguous",
+		}, fieldSummary(st.AllFields()))
+	})
+
+	t.Run("same type at different depths", func(t *testing.T) {
+		type leaf struct{ X int }
+		type middle struct{ leaf }
+		st, _ := anyiter.NewSafeType(reflect.TypeOf(struct {
+			leaf
+			middle
+		}{})).AsStruct()
+		assert.Equal(t, []string{
+			"leaf [0]",
+			"middle [1]",
+			"X [0 0]",
+			"leaf [1 0] shadowed",
+			"X [1 0 0] shadowed",
+		}, fieldSummary(st.AllFields()))
+	})
+
+	t.Run("embedded pointer cycle", func(t *testing.T) {
+		st, _ := anyiter.TypeOf[testLink]().AsStruct()
+		assert.Equal(t, []string{"testLink [0]", "Value [1]"}, fieldSummary(st.AllFields()))
+	})
+}
@@ -0,0 +1,130 @@
+package anyiter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// SafeFunc is a wrapper for a func value that checks every call against the func's signature, returning errors in
+// the cases where reflect.Value.Call and CallSlice would panic. A panic in the func itself is not recovered.
+type SafeFunc interface {
+	// ReflectValue returns the underlying reflect.Value
+	ReflectValue() reflect.Value
+
+	// Type returns the func's signature. Its In, Out and IsVariadic methods describe the parameters and results.
+	Type() SafeType
+
+	// IsNil reports whether the func is nil.
+	IsNil() bool
+
+	// Call calls the func with the input arguments in.
+	// For example, if len(in) == 3, f.Call(in) represents the Go call f(in[0], in[1], in[2]).
+	// It errors if the func is nil, if the number of arguments does not match the func's signature or if an argument
+	// is not assignable to its parameter type.
+	// If the func is variadic, Call creates the variadic slice parameter itself, copying in the corresponding values.
+	Call(in []SafeValue) ([]SafeValue, error)
+
+	// CallSlice calls the variadic func with the input arguments in, assigning the slice in[len(in)-1] to the func's
+	// final variadic parameter.
+	// For example, if len(in) == 3, f.CallSlice(in) represents the Go call f(in[0], in[1], in[2]...).
+	// It errors if the func is nil or not variadic, if the number of arguments does not match the func's signature
+	// or if an argument is not assignable to its parameter type.
+	CallSlice(in []SafeValue) ([]SafeValue, error)
+}
+
+type safeFunc struct {
+	value reflect.Value
+}
+
+// NewSafeFunc wraps a func value in the SafeFunc interface. It errors if the value's Kind is not Func, or if the value
+// was obtained using an unexported field and so can't be called.
+func NewSafeFunc(value reflect.Value) (SafeFunc, error) {
+	if err := checkKind("NewSafeFunc", value.Kind(), reflect.Func); err != nil {
+		return nil, err
+	}
+	if !value.CanInterface() {
+		return nil, ErrUnexported
+	}
+	return &safeFunc{value: value}, nil
+}
+
+func (s safeFunc) ReflectValue() reflect.Value {
+	return s.value
+}
+
+func (s safeFunc) Type() SafeType {
+	return NewSafeType(s.value.Type())
+}
+
+func (s safeFunc) IsNil() bool {
+	return s.value.IsNil()
+}
+
+func (s safeFunc) Call(in []SafeValue) ([]SafeValue, error) {
+	args, err := s.check(in, false)
+	if err != nil {
+		return nil, err
+	}
+	return wrapValues(s.value.Call(args)), nil
+}
+
+func (s safeFunc) CallSlice(in []SafeValue) ([]SafeValue, error) {
+	args, err := s.check(in, true)
+	if err != nil {
+		return nil, err
+	}
+	return wrapValues(s.value.CallSlice(args)), nil
+}
+
+// check returns the arguments of a call as reflect.Values, after checking they match the func's signature.
+func (s safeFunc) check(in []SafeValue, spread bool) ([]reflect.Value, error) {
+	t := s.value.Type()
+	if s.value.IsNil() {
+		return nil, errors.New("func is nil")
+	}
+
+	n := t.NumIn()
+	isVariadic := t.IsVariadic()
+	switch {
+	case spread && !isVariadic:
+		return nil, fmt.Errorf("%s is not variadic", t)
+	case spread && len(in) != n:
+		return nil, fmt.Errorf("wrong number of arguments for %s: got %d, want %d", t, len(in), n)
+	case !spread && isVariadic && len(in) < n-1:
+		return nil, fmt.Errorf("wrong number of arguments for %s: got %d, want at least %d", t, len(in), n-1)
+	case !spread && !isVariadic && len(in) != n:
+		return nil, fmt.Errorf("wrong number of arguments for %s: got %d, want %d", t, len(in), n)
+	}
+
+	args := make([]reflect.Value, len(in))
+	for i, arg := range in {
+		args[i] = reflectValueOf(arg)
+		if !args[i].IsValid() {
+			return nil, fmt.Errorf("argument %d is invalid", i)
+		}
+		if !args[i].CanInterface() {
+			return nil, fmt.Errorf("argument %d: %w", i, ErrUnexported)
+		}
+
+		target := paramType(t, i, spread)
+		if !args[i].Type().AssignableTo(target) {
+			if spread && i == n-1 {
+				return nil, fmt.Errorf("argument %d: %s is not assignable to variadic parameter %s", i,
+					args[i].Type(), target)
+			}
+			return nil, fmt.Errorf("argument %d: %s is not assignable to %s", i, args[i].Type(), target)
+		}
+	}
+	return args, nil
+}
+
+// paramType returns the type argument i of a call to a func of type t must be assignable to. Unless the variadic
+// arguments are spread, each of them is assigned to an element of the variadic parameter.
+func paramType(t reflect.Type, i int, spread bool) reflect.Type {
+	n := t.NumIn()
+	if t.IsVariadic() && !spread && i >= n-1 {
+		return t.In(n - 1).Elem()
+	}
+	return t.In(i)
+}
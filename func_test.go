@@ -0,0 +1,152 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+// safeValues wraps each of vals in a SafeValue.
+func safeValues(vals ...any) []anyiter.SafeValue {
+	in := make([]anyiter.SafeValue, len(vals))
+	for i, v := range vals {
+		in[i] = anyiter.NewSafeValue(reflect.ValueOf(v))
+	}
+	return in
+}
+
+func TestNewSafeFunc(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeFunc(reflect.ValueOf(1))
+		assertKindError(t, err, "NewSafeFunc", reflect.Func)
+	})
+
+	t.Run("unexported", func(t *testing.T) {
+		v := reflect.ValueOf(struct{ fn func() }{}).Field(0)
+		_, err := anyiter.NewSafeFunc(v)
+		assert.Equal(t, anyiter.ErrUnexported, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		fn, err := anyiter.NewSafeFunc(reflect.ValueOf(func() {}))
+		assert.NotNil(t, fn)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeFunc_ReflectValue(t *testing.T) {
+	v := reflect.ValueOf(func() {})
+	fn, _ := anyiter.NewSafeFunc(v)
+	assert.Equal(t, v.Pointer(), fn.ReflectValue().Pointer())
+}
+
+func TestSafeFunc_Type(t *testing.T) {
+	fn, _ := anyiter.NewSafeFunc(reflect.ValueOf(func(string, ...int) error { return nil }))
+
+	in, err := fn.Type().In(1)
+	assert.Nil(t, err)
+	assert.Equal(t, reflect.TypeOf([]int{}), in.ReflectType())
+
+	out, err := fn.Type().Out(0)
+	assert.Nil(t, err)
+	assert.Equal(t, "error", out.String())
+
+	isVariadic, err := fn.Type().IsVariadic()
+	assert.Nil(t, err)
+	assert.True(t, isVariadic)
+}
+
+func TestSafeFunc_IsNil(t *testing.T) {
+	var nilFn func()
+	fn, _ := anyiter.NewSafeFunc(reflect.ValueOf(nilFn))
+	assert.True(t, fn.IsNil())
+
+	fn, _ = anyiter.NewSafeFunc(reflect.ValueOf(func() {}))
+	assert.False(t, fn.IsNil())
+}
+
+func TestSafeFunc_Call(t *testing.T) {
+	join := func(sep string, vals ...int) string {
+		s := ""
+		for i, val := range vals {
+			if i > 0 {
+				s += sep
+			}
+			s += string(rune('0' + val))
+		}
+		return s
+	}
+	fn, _ := anyiter.NewSafeFunc(reflect.ValueOf(join))
+
+	t.Run("nil func", func(t *testing.T) {
+		var nilFn func()
+		fn, _ := anyiter.NewSafeFunc(reflect.ValueOf(nilFn))
+		_, err := fn.Call(nil)
+		assert.Equal(t, errors.New("func is nil"), err)
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		_, err := fn.Call(nil)
+		assert.Equal(t, errors.New("wrong number of arguments for func(string, ...int) string: got 0, want at least 1"), err)
+	})
+
+	t.Run("unassignable variadic argument", func(t *testing.T) {
+		_, err := fn.Call(safeValues(",", 1, "2"))
+		assert.Equal(t, errors.New("argument 2: string is not assignable to int"), err)
+	})
+
+	t.Run("unexported argument", func(t *testing.T) {
+		sv := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 1}))
+		field, _ := sv.Field(0)
+		_, err := fn.Call([]anyiter.SafeValue{anyiter.NewSafeValue(reflect.ValueOf(",")), field})
+		assert.True(t, errors.Is(err, anyiter.ErrUnexported))
+		assert.EqualError(t, err, "argument 1: value was obtained using an unexported field")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := fn.Call(safeValues(",", 1, 2))
+		assert.Nil(t, err)
+		assert.Equal(t, "1,2", out[0].String())
+
+		out, err = fn.Call(safeValues(","))
+		assert.Nil(t, err)
+		assert.Equal(t, "", out[0].String())
+	})
+}
+
+func TestSafeFunc_CallSlice(t *testing.T) {
+	sum := func(base int, vals ...int) int {
+		for _, val := range vals {
+			base += val
+		}
+		return base
+	}
+	fn, _ := anyiter.NewSafeFunc(reflect.ValueOf(sum))
+
+	t.Run("not variadic", func(t *testing.T) {
+		fn, _ := anyiter.NewSafeFunc(reflect.ValueOf(func(int) {}))
+		_, err := fn.CallSlice(safeValues(1))
+		assert.Equal(t, errors.New("func(int) is not variadic"), err)
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		_, err := fn.CallSlice(safeValues(1, 2, 3))
+		assert.Equal(t, errors.New("wrong number of arguments for func(int, ...int) int: got 3, want 2"), err)
+	})
+
+	t.Run("mismatched spread", func(t *testing.T) {
+		_, err := fn.CallSlice(safeValues(1, 2))
+		assert.Equal(t, errors.New("argument 1: int is not assignable to variadic parameter []int"), err)
+
+		_, err = fn.CallSlice(safeValues(1, []string{"2"}))
+		assert.Equal(t, errors.New("argument 1: []string is not assignable to variadic parameter []int"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := fn.CallSlice(safeValues(1, []int{2, 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, 6, out[0].ReflectValue().Interface())
+	})
+}
@@ -0,0 +1,40 @@
+package anyiter
+
+import (
+	"errors"
+	"reflect"
+)
+
+// TypeOf returns the SafeType of the type parameter T. Unlike reflect.TypeOf, it works for interface types: the
+// SafeType of an interface type T has Kind Interface.
+func TypeOf[T any]() SafeType {
+	return NewSafeType(reflect.TypeFor[T]())
+}
+
+// ValueOf returns a SafeValue holding v, with type T. Unlike reflect.ValueOf, it works for interface types: if T is
+// an interface type, the SafeValue has Kind Interface and holds v as an interface value, even if v is nil.
+func ValueOf[T any](v T) SafeValue {
+	if reflect.TypeFor[T]().Kind() == reflect.Interface {
+		return NewSafeValue(reflect.ValueOf(&v).Elem())
+	}
+	return NewSafeValue(reflect.ValueOf(v))
+}
+
+// As returns the value v holds as a T. It errors if v is invalid or was obtained using an unexported field, and
+// returns an *AssignableError if v's type is not assignable to T.
+func As[T any](v SafeValue) (T, error) {
+	var t T
+	rv := reflectValueOf(v)
+	if !rv.IsValid() {
+		return t, errors.New("value is invalid")
+	}
+	if !rv.CanInterface() {
+		return t, ErrUnexported
+	}
+	if err := ExplainAssignable(NewSafeType(rv.Type()), TypeOf[T]()); err != nil {
+		return t, err
+	}
+
+	reflect.ValueOf(&t).Elem().Set(rv)
+	return t, nil
+}
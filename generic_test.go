@@ -0,0 +1,85 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestTypeOf(t *testing.T) {
+	assert.True(t, anyiter.NewSafeType(reflect.TypeOf(testStruct{})) == anyiter.TypeOf[testStruct]())
+	assert.Equal(t, reflect.Interface, anyiter.TypeOf[testInterface]().Kind())
+	assert.Equal(t, reflect.Interface, anyiter.TypeOf[any]().Kind())
+	assert.Equal(t, reflect.Ptr, anyiter.TypeOf[*int]().Kind())
+}
+
+func TestValueOf(t *testing.T) {
+	t.Run("concrete", func(t *testing.T) {
+		v := anyiter.ValueOf(5)
+		i, err := v.Int()
+		assert.Equal(t, int64(5), i)
+		assert.Nil(t, err)
+		assert.False(t, v.CanSet())
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		v := anyiter.ValueOf[testInterface](testStruct{someField: 1})
+		typ, _ := v.Type()
+		assert.True(t, typ == anyiter.TypeOf[testInterface]())
+
+		elem, err := v.Elem()
+		assert.Nil(t, err)
+		typ, _ = elem.Type()
+		assert.True(t, typ == anyiter.TypeOf[testStruct]())
+	})
+
+	t.Run("nil interface", func(t *testing.T) {
+		v := anyiter.ValueOf[error](nil)
+		assert.True(t, v.IsValid())
+		isNil, err := v.IsNil()
+		assert.True(t, isNil)
+		assert.Nil(t, err)
+	})
+}
+
+func TestAs(t *testing.T) {
+	t.Run("concrete", func(t *testing.T) {
+		i, err := anyiter.As[int](anyiter.ValueOf(5))
+		assert.Equal(t, 5, i)
+		assert.Nil(t, err)
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		iface, err := anyiter.As[testInterface](anyiter.ValueOf(testStruct{someField: 1}))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, iface.GetSomeField())
+
+		e, err := anyiter.As[error](anyiter.ValueOf[error](nil))
+		assert.Nil(t, e)
+		assert.Nil(t, err)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := anyiter.As[string](anyiter.ValueOf(5))
+		var assignableErr *anyiter.AssignableError
+		assert.True(t, errors.As(err, &assignableErr))
+		assert.EqualError(t, err, "int is not assignable to string: an explicit conversion is needed")
+
+		_, err = anyiter.As[testInterface](anyiter.ValueOf(1))
+		var implementsErr *anyiter.ImplementsError
+		assert.True(t, errors.As(err, &implementsErr))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.As[int](nil)
+		assert.EqualError(t, err, "value is invalid")
+	})
+
+	t.Run("unexported field", func(t *testing.T) {
+		field, _ := anyiter.ValueOf(testStruct{someField: 1}).Field(0)
+		_, err := anyiter.As[int](field)
+		assert.True(t, errors.Is(err, anyiter.ErrUnexported))
+	})
+}
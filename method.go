@@ -1,15 +1,34 @@
 package anyiter
 
-import "reflect"
+import (
+	"errors"
+	"reflect"
+)
 
 // SafeMethod is an interface wrapper for reflect.Method, with Type and Func modified to return SafeType and SafeFunc
 type SafeMethod interface {
+	// ReflectMethod returns the underlying reflect.Method
 	ReflectMethod() reflect.Method
+
+	// Name is the method name.
 	Name() string
+
+	// PkgPath is the package path that qualifies a lower case (unexported)
+	// method name. It is empty for upper case (exported) method names.
 	PkgPath() string
 
+	// Type returns the method's signature.
+	//
+	// For a non-interface type T or *T, the signature is that of a function
+	// whose first argument is the receiver. For an interface type, the
+	// signature doesn't include a receiver.
 	Type() SafeType
-	Func() SafeValue
+
+	// Func returns the method as a function value whose first argument is the receiver.
+	// It errors for a method of an interface type, which has no function value.
+	Func() (SafeFunc, error)
+
+	// Index returns the method's index in the type's method set, for use with SafeType.Method.
 	Index() int
 }
 
@@ -27,21 +46,24 @@ func (s safeMethod) ReflectMethod() reflect.Method {
 }
 
 func (s safeMethod) Name() string {
-	panic("implement me")
+	return s.method.Name
 }
 
 func (s safeMethod) PkgPath() string {
-	panic("implement me")
+	return s.method.PkgPath
 }
 
 func (s safeMethod) Type() SafeType {
-	panic("implement me")
+	return NewSafeType(s.method.Type)
 }
 
-func (s safeMethod) Func() SafeValue {
-	panic("implement me")
+func (s safeMethod) Func() (SafeFunc, error) {
+	if !s.method.Func.IsValid() {
+		return nil, errors.New("method of an interface type has no Func")
+	}
+	return NewSafeFunc(s.method.Func)
 }
 
 func (s safeMethod) Index() int {
-	panic("implement me")
+	return s.method.Index
 }
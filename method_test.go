@@ -0,0 +1,73 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestSafeMethod_ReflectMethod(t *testing.T) {
+	m := reflect.TypeOf(testStruct{}).Method(0)
+	assert.Equal(t, m.Name, anyiter.NewSafeMethod(m).ReflectMethod().Name)
+	assert.Equal(t, m.Type, anyiter.NewSafeMethod(m).ReflectMethod().Type)
+}
+
+func TestSafeMethod_Name(t *testing.T) {
+	method, _ := anyiter.NewSafeType(reflect.TypeOf(testStruct{})).MethodByName("GetSomeField")
+	assert.Equal(t, "GetSomeField", method.Name())
+}
+
+func TestSafeMethod_PkgPath(t *testing.T) {
+	t.Run("exported", func(t *testing.T) {
+		method, _ := anyiter.NewSafeType(reflect.TypeOf(testStruct{})).MethodByName("GetSomeField")
+		assert.Equal(t, "", method.PkgPath())
+	})
+
+	t.Run("unexported", func(t *testing.T) {
+		typ := reflect.TypeOf((*interface{ unexported() })(nil)).Elem()
+		method, _ := anyiter.NewSafeType(typ).Method(0)
+		assert.Equal(t, typ.Method(0).PkgPath, method.PkgPath())
+		assert.NotEqual(t, "", method.PkgPath())
+	})
+}
+
+func TestSafeMethod_Type(t *testing.T) {
+	t.Run("concrete", func(t *testing.T) {
+		method, _ := anyiter.NewSafeType(reflect.TypeOf(testStruct{})).MethodByName("GetSomeField")
+		assert.Equal(t, reflect.TypeOf(testStruct.GetSomeField), method.Type().ReflectType())
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		typ := reflect.TypeOf((*testInterface)(nil)).Elem()
+		method, _ := anyiter.NewSafeType(typ).MethodByName("GetSomeField")
+		assert.Equal(t, reflect.TypeOf(func() int { return 0 }), method.Type().ReflectType())
+	})
+}
+
+func TestSafeMethod_Func(t *testing.T) {
+	t.Run("concrete", func(t *testing.T) {
+		method, _ := anyiter.NewSafeType(reflect.TypeOf(testStruct{})).MethodByName("GetSomeField")
+		fn, err := method.Func()
+		assert.Nil(t, err)
+
+		out, err := fn.Call([]anyiter.SafeValue{anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5}))})
+		assert.Nil(t, err)
+		i, _ := out[0].Int()
+		assert.Equal(t, int64(5), i)
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		typ := reflect.TypeOf((*testInterface)(nil)).Elem()
+		method, _ := anyiter.NewSafeType(typ).MethodByName("GetSomeField")
+		fn, err := method.Func()
+		assert.Nil(t, fn)
+		assert.Equal(t, errors.New("method of an interface type has no Func"), err)
+	})
+}
+
+func TestSafeMethod_Index(t *testing.T) {
+	method, _ := anyiter.NewSafeType(reflect.TypeOf(testStruct{})).MethodByName("GetSomeField")
+	assert.Equal(t, 1, method.Index())
+}
@@ -0,0 +1,139 @@
+package anyiter
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// MethodSets lists the method set of a type T next to the method set of *T. Every method of T is also a method of
+// *T, but methods with a pointer receiver, declared on *T or promoted through an embedded *E or E, are only methods
+// of *T.
+type MethodSets struct {
+	// Value is the method set of T, sorted by name.
+	Value []MethodSetEntry
+	// Pointer is the method set of *T, sorted by name.
+	Pointer []MethodSetEntry
+}
+
+// MethodSetEntry is a method in a method set, with the embedding chain it was promoted through.
+type MethodSetEntry struct {
+	// Method is the method, as returned by SafeType.Method.
+	Method SafeMethod
+
+	// Embedding is the chain of embedded fields the method was promoted through, starting with a field of T. It is
+	// empty for a method declared on T or *T itself.
+	Embedding []reflect.StructField
+
+	// PointerOnly reports whether the method is in the method set of *T but not of T.
+	PointerOnly bool
+}
+
+// Promoted reports whether the method was promoted from an embedded field.
+func (e MethodSetEntry) Promoted() bool {
+	return len(e.Embedding) > 0
+}
+
+// MethodSetsOf returns the method sets of t and of a pointer to t. If t is a pointer type, it returns those of the
+// type t points to instead, so a pointer's own method set is in Pointer.
+//
+// Methods are matched to the embedded fields that declare them using Go's selector rules. A method found in both t
+// and an embedded field is taken to be declared on t if its function isn't compiler generated, because a promoted
+// method is always called through a generated wrapper.
+func MethodSetsOf(t SafeType) MethodSets {
+	rt := t.ReflectType()
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	pt := reflect.PointerTo(rt)
+
+	var sets MethodSets
+	for i := 0; i < rt.NumMethod(); i++ {
+		m := rt.Method(i)
+		sets.Value = append(sets.Value, MethodSetEntry{Method: NewSafeMethod(m), Embedding: embedding(rt, m.Name)})
+	}
+	for i := 0; i < pt.NumMethod(); i++ {
+		m := pt.Method(i)
+		_, inValue := rt.MethodByName(m.Name)
+		sets.Pointer = append(sets.Pointer, MethodSetEntry{
+			Method:      NewSafeMethod(m),
+			Embedding:   embedding(rt, m.Name),
+			PointerOnly: !inValue,
+		})
+	}
+	return sets
+}
+
+// embedding returns the chain of embedded fields the method called name of t or *t was promoted through, or nil if
+// it is declared on t or *t.
+func embedding(t reflect.Type, name string) []reflect.StructField {
+	return promotion(t, name, map[reflect.Type]bool{})
+}
+
+func promotion(t reflect.Type, name string, visiting map[reflect.Type]bool) []reflect.StructField {
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return nil
+	}
+
+	var candidates []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && hasMethod(indirectType(field.Type), name) {
+			candidates = append(candidates, field)
+		}
+	}
+	if len(candidates) == 0 || declares(t, name) {
+		return nil
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	// The method comes from the embedded field it is promoted through in the fewest steps. Go leaves out methods
+	// promoted through several fields at the same depth, so there is only ever one.
+	var best []reflect.StructField
+	for _, field := range candidates {
+		chain := append([]reflect.StructField{field}, promotion(indirectType(field.Type), name, visiting)...)
+		if best == nil || len(chain) < len(best) {
+			best = chain
+		}
+	}
+	return best
+}
+
+// declares reports whether the struct type t or *t declares the method called name, as opposed to having it
+// promoted from an embedded field.
+func declares(t reflect.Type, name string) bool {
+	m, ok := t.MethodByName(name)
+	if !ok {
+		// The method of *t that wraps a method declared on t is generated too, so t is checked first.
+		if m, ok = reflect.PointerTo(t).MethodByName(name); !ok {
+			return false
+		}
+	}
+
+	f := runtime.FuncForPC(m.Func.Pointer())
+	if f == nil {
+		return false
+	}
+	file, _ := f.FileLine(f.Entry())
+	return file != "<autogenerated>"
+}
+
+// hasMethod reports whether t or *t has a method called name.
+func hasMethod(t reflect.Type, name string) bool {
+	if _, ok := t.MethodByName(name); ok {
+		return true
+	}
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+	_, ok := reflect.PointerTo(t).MethodByName(name)
+	return ok
+}
+
+// indirectType returns the element type of a pointer type, or t itself otherwise.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,102 @@
+package anyiter_test
+
+import (
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type testBase struct{}
+
+func (testBase) ID() int       { return 1 }
+func (*testBase) SetID(int)    {}
+func (testBase) Describe() int { return 0 }
+
+type testMiddle struct {
+	*testBase
+}
+
+type testOuter struct {
+	testMiddle
+	io.Reader
+}
+
+func (testOuter) Describe() int { return 2 }
+func (*testOuter) Reset()       {}
+
+// methodSetSummary returns the name, embedding chain and pointer-only flag of each entry.
+func methodSetSummary(entries []anyiter.MethodSetEntry) map[string][]string {
+	summary := map[string][]string{}
+	for _, e := range entries {
+		var chain []string
+		for _, f := range e.Embedding {
+			chain = append(chain, f.Name)
+		}
+		if e.PointerOnly {
+			chain = append(chain, "pointer only")
+		}
+		summary[e.Method.Name()] = chain
+	}
+	return summary
+}
+
+func TestMethodSetsOf(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		sets := anyiter.MethodSetsOf(anyiter.NewSafeType(reflect.TypeOf(testStruct{})))
+		assert.Equal(t, map[string][]string{"AddToField": nil, "GetSomeField": nil}, methodSetSummary(sets.Value))
+		assert.Equal(t, map[string][]string{"AddToField": nil, "GetSomeField": nil}, methodSetSummary(sets.Pointer))
+	})
+
+	t.Run("promoted", func(t *testing.T) {
+		sets := anyiter.MethodSetsOf(anyiter.NewSafeType(reflect.TypeOf(testOuter{})))
+
+		// SetID is promoted through an embedded pointer, so it is in the method set of the value.
+		assert.Equal(t, map[string][]string{
+			"Describe": nil,
+			"ID":       {"testMiddle", "testBase"},
+			"Read":     {"Reader"},
+			"SetID":    {"testMiddle", "testBase"},
+		}, methodSetSummary(sets.Value))
+		assert.Equal(t, map[string][]string{
+			"Describe": nil,
+			"ID":       {"testMiddle", "testBase"},
+			"Read":     {"Reader"},
+			"Reset":    {"pointer only"},
+			"SetID":    {"testMiddle", "testBase"},
+		}, methodSetSummary(sets.Pointer))
+		assert.True(t, sets.Value[1].Promoted())
+		assert.False(t, sets.Value[0].Promoted())
+	})
+
+	t.Run("pointer receiver through embedded value", func(t *testing.T) {
+		type wrapper struct {
+			testBase
+		}
+		sets := anyiter.MethodSetsOf(anyiter.NewSafeType(reflect.TypeOf(wrapper{})))
+		assert.Equal(t, map[string][]string{
+			"Describe": {"testBase"},
+			"ID":       {"testBase"},
+		}, methodSetSummary(sets.Value))
+		assert.Equal(t, map[string][]string{
+			"Describe": {"testBase"},
+			"ID":       {"testBase"},
+			"SetID":    {"testBase", "pointer only"},
+		}, methodSetSummary(sets.Pointer))
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		sets := anyiter.MethodSetsOf(anyiter.TypeOf[*testOuter]())
+		elemSets := anyiter.MethodSetsOf(anyiter.TypeOf[testOuter]())
+		assert.Equal(t, methodSetSummary(elemSets.Value), methodSetSummary(sets.Value))
+		assert.Equal(t, methodSetSummary(elemSets.Pointer), methodSetSummary(sets.Pointer))
+		assert.Equal(t, []string{"testMiddle", "testBase"}, methodSetSummary(sets.Pointer)["ID"])
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		sets := anyiter.MethodSetsOf(anyiter.TypeOf[testInterface]())
+		assert.Equal(t, map[string][]string{"GetSomeField": nil}, methodSetSummary(sets.Value))
+		assert.Empty(t, sets.Pointer)
+	})
+}
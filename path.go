@@ -0,0 +1,70 @@
+package anyiter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StepKind is the kind of a single PathStep.
+type StepKind int
+
+const (
+	// FieldStep selects a struct field.
+	FieldStep StepKind = iota
+	// IndexStep selects an element of a slice, array, string or channel.
+	IndexStep
+	// KeyStep selects a map entry.
+	KeyStep
+)
+
+// PathStep is a single step from a value to one of its elements.
+type PathStep struct {
+	// Kind is the kind of step.
+	Kind StepKind
+
+	// Name is the struct field name of a FieldStep.
+	Name string
+
+	// Index is the field index of a FieldStep, or the element index of an IndexStep. For strings it is the byte
+	// offset of the rune, and for channels and the entries of a KeyStep the number of values visited before this one.
+	Index int
+
+	// Key is the map key of a KeyStep.
+	Key SafeValue
+}
+
+// String returns the step formatted as a Go selector or index expression, such as .Name, [3] or ["key"].
+func (s PathStep) String() string {
+	switch s.Kind {
+	case FieldStep:
+		return "." + s.Name
+	case IndexStep:
+		return fmt.Sprintf("[%d]", s.Index)
+	case KeyStep:
+		key := reflectValueOf(s.Key)
+		if key.Kind() == reflect.String {
+			return fmt.Sprintf("[%q]", key)
+		}
+		return fmt.Sprintf("[%v]", key)
+	}
+	return ""
+}
+
+// Path is the sequence of steps from the root of an iteration to a value. Pointers and interfaces are followed
+// without adding a step. The root itself has an empty Path.
+type Path []PathStep
+
+// String returns the path formatted as a Go expression relative to the root, such as .Orders[3].Items["sku"].Price.
+func (p Path) String() string {
+	var b strings.Builder
+	for _, step := range p {
+		b.WriteString(step.String())
+	}
+	return b.String()
+}
+
+// append returns a new Path with step added, leaving p untouched.
+func (p Path) append(step PathStep) Path {
+	return append(p[:len(p):len(p)], step)
+}
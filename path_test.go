@@ -0,0 +1,42 @@
+package anyiter_test
+
+import (
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestPathStep_String(t *testing.T) {
+	t.Run("field", func(t *testing.T) {
+		step := anyiter.PathStep{Kind: anyiter.FieldStep, Name: "Orders", Index: 0}
+		assert.Equal(t, ".Orders", step.String())
+	})
+
+	t.Run("index", func(t *testing.T) {
+		step := anyiter.PathStep{Kind: anyiter.IndexStep, Index: 3}
+		assert.Equal(t, "[3]", step.String())
+	})
+
+	t.Run("string key", func(t *testing.T) {
+		step := anyiter.PathStep{Kind: anyiter.KeyStep, Key: anyiter.NewSafeValue(reflect.ValueOf("sku"))}
+		assert.Equal(t, `["sku"]`, step.String())
+	})
+
+	t.Run("int key", func(t *testing.T) {
+		step := anyiter.PathStep{Kind: anyiter.KeyStep, Key: anyiter.NewSafeValue(reflect.ValueOf(7))}
+		assert.Equal(t, "[7]", step.String())
+	})
+}
+
+func TestPath_String(t *testing.T) {
+	path := anyiter.Path{
+		{Kind: anyiter.FieldStep, Name: "Orders"},
+		{Kind: anyiter.IndexStep, Index: 3},
+		{Kind: anyiter.FieldStep, Name: "Items", Index: 1},
+		{Kind: anyiter.KeyStep, Key: anyiter.NewSafeValue(reflect.ValueOf("sku"))},
+		{Kind: anyiter.FieldStep, Name: "Price"},
+	}
+	assert.Equal(t, `.Orders[3].Items["sku"].Price`, path.String())
+	assert.Equal(t, "", anyiter.Path{}.String())
+}
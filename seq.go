@@ -0,0 +1,109 @@
+package anyiter
+
+import (
+	goiter "iter"
+	"reflect"
+)
+
+// Seq returns an iter.Seq over the values of the elements after it, for use with range-over-func:
+//
+//	for v := range anyiter.Seq(anyiter.NewIter(x)) {
+//		...
+//	}
+//
+// Since Seq has no way to return an error, check it.Err once the loop finishes.
+func Seq(it Iter) goiter.Seq[SafeValue] {
+	return func(yield func(SafeValue) bool) {
+		for next := it.Next(); next != nil; next = next.Next() {
+			if !yield(next.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns an iter.Seq2 over the keys and values of the elements after it. The key of a map entry is its map
+// key, and the key of any other element is its Index as an int.
+// Since Seq2 has no way to return an error, check it.Err once the loop finishes.
+func Seq2(it Iter) goiter.Seq2[SafeValue, SafeValue] {
+	return func(yield func(SafeValue, SafeValue) bool) {
+		for next := it.Next(); next != nil; next = next.Next() {
+			key := next.Key()
+			if !key.IsValid() {
+				key = NewSafeValue(reflect.ValueOf(next.Index()))
+			}
+			if !yield(key, next.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iter.Seq2 over the keys and values of the elements of v, as iterated by NewIter:
+//
+//	for k, v := range anyiter.All(x) {
+//		...
+//	}
+func All(v any) goiter.Seq2[SafeValue, SafeValue] {
+	return Seq2(NewIter(v))
+}
+
+// FromSeq returns an Iter positioned before the values of seq. Each call to Next pulls the next value from seq, and
+// the Index of each element is its position in seq. The root of the iteration holds the invalid SafeValue.
+//
+// Like iter.Pull, FromSeq also returns a stop func, which ends the iteration and releases seq. An iteration that's
+// abandoned before seq is exhausted must call it, usually with defer; calling it more than once is harmless. Next
+// returns nil once stop has been called.
+func FromSeq(seq goiter.Seq[SafeValue]) (Iter, func()) {
+	next, stop := goiter.Pull(seq)
+	return newSeqIter(func() (SafeValue, SafeValue, bool) {
+		v, ok := next()
+		return nil, v, ok
+	}, stop), stop
+}
+
+// FromSeq2 returns an Iter positioned before the key-value pairs of seq. Each call to Next pulls the next pair from
+// seq; the Key of each element is the key from seq and its Index is its position in seq. The root of the iteration
+// holds the invalid SafeValue.
+//
+// FromSeq2 also returns a stop func, which must be called like the one FromSeq returns.
+func FromSeq2(seq goiter.Seq2[SafeValue, SafeValue]) (Iter, func()) {
+	next, stop := goiter.Pull2(seq)
+	return newSeqIter(next, stop), stop
+}
+
+func newSeqIter(next func() (SafeValue, SafeValue, bool), stop func()) Iter {
+	return &iter{walker: &seqWalker{pull: next, stop: stop}}
+}
+
+// seqWalker visits the values pulled from a sequence.
+type seqWalker struct {
+	pull   func() (SafeValue, SafeValue, bool)
+	stop   func()
+	pulled int
+}
+
+func (w *seqWalker) advance(from *iter) *iter {
+	key, value, ok := w.pull()
+	if !ok {
+		w.stop()
+		return nil
+	}
+	w.pulled++
+
+	step := PathStep{Kind: IndexStep, Index: w.pulled - 1}
+	if key != nil {
+		step = PathStep{Kind: KeyStep, Index: w.pulled - 1, Key: key}
+	}
+
+	// The root is the iter without a parent.
+	parent := from.parent
+	if parent == nil {
+		parent = from
+	}
+	return &iter{value: reflectValueOf(value), path: Path{step}, parent: parent, walker: w}
+}
+
+func (w *seqWalker) err() error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package anyiter_test
+
+import (
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		var values []any
+		for v := range anyiter.Seq(anyiter.NewIter([]int{1, 2, 3})) {
+			i, _ := v.Interface()
+			values = append(values, i)
+		}
+		assert.Equal(t, []any{1, 2, 3}, values)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var values []any
+		for v := range anyiter.Seq(anyiter.NewIter([]int{1, 2, 3})) {
+			i, _ := v.Interface()
+			values = append(values, i)
+			break
+		}
+		assert.Equal(t, []any{1}, values)
+	})
+}
+
+func TestSeq2(t *testing.T) {
+	t.Run("slice", func(t *testing.T) {
+		var keys, values []any
+		for k, v := range anyiter.Seq2(anyiter.NewIter([]string{"a", "b"})) {
+			key, _ := k.Interface()
+			value, _ := v.Interface()
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		assert.Equal(t, []any{0, 1}, keys)
+		assert.Equal(t, []any{"a", "b"}, values)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		entries := map[string]int{}
+		for k, v := range anyiter.Seq2(anyiter.NewIter(map[string]int{"a": 1, "b": 2})) {
+			i, _ := v.Int()
+			entries[k.String()] = int(i)
+		}
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, entries)
+	})
+}
+
+func TestAll(t *testing.T) {
+	entries := map[string]int{}
+	for k, v := range anyiter.All(map[string]int{"a": 1}) {
+		i, _ := v.Int()
+		entries[k.String()] = int(i)
+	}
+	assert.Equal(t, map[string]int{"a": 1}, entries)
+}
+
+func TestFromSeq(t *testing.T) {
+	seq := func(yield func(anyiter.SafeValue) bool) {
+		for _, s := range []string{"a", "b"} {
+			if !yield(anyiter.NewSafeValue(reflect.ValueOf(s))) {
+				return
+			}
+		}
+	}
+
+	it, stop := anyiter.FromSeq(seq)
+	defer stop()
+	assert.False(t, it.Value().IsValid())
+	assert.Equal(t, []any{"a", "b"}, collect(it))
+	assert.Equal(t, 1, it.Next().Next().Index())
+	assert.Equal(t, it, it.Next().Next().Parent())
+	assert.Nil(t, it.Err())
+
+	t.Run("stop", func(t *testing.T) {
+		released := false
+		it, stop := anyiter.FromSeq(func(yield func(anyiter.SafeValue) bool) {
+			defer func() { released = true }()
+			for yield(anyiter.NewSafeValue(reflect.ValueOf(1))) {
+			}
+		})
+		assert.NotNil(t, it.Next())
+		assert.False(t, released)
+
+		stop()
+		assert.True(t, released)
+		assert.Nil(t, it.Next().Next())
+		stop()
+	})
+}
+
+func TestFromSeq2(t *testing.T) {
+	seq := func(yield func(anyiter.SafeValue, anyiter.SafeValue) bool) {
+		yield(anyiter.NewSafeValue(reflect.ValueOf("a")), anyiter.NewSafeValue(reflect.ValueOf(1)))
+	}
+
+	root, stop := anyiter.FromSeq2(seq)
+	defer stop()
+	it := root.Next()
+	assert.Equal(t, "a", it.Key().String())
+	i, _ := it.Value().Int()
+	assert.Equal(t, int64(1), i)
+	assert.Equal(t, `["a"]`, it.Path().String())
+	assert.Nil(t, it.Next())
+
+	t.Run("round trip", func(t *testing.T) {
+		entries := map[string]int{}
+		it, stop := anyiter.FromSeq2(anyiter.All(map[string]int{"a": 1, "b": 2}))
+		defer stop()
+		for k, v := range anyiter.Seq2(it) {
+			i, _ := v.Int()
+			entries[k.String()] = int(i)
+		}
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, entries)
+	})
+}
@@ -0,0 +1,133 @@
+package anyiter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Tags is a struct tag parsed into its key:"value" pairs, in the order they appear.
+type Tags []Tag
+
+// Tag is a single key:"value" pair of a struct tag. By convention, the value is a name followed by comma separated
+// options, like json:"user_id,omitempty".
+type Tag struct {
+	// Key is the key of the pair, like json.
+	Key string
+	// Value is the unquoted value of the pair, like user_id,omitempty.
+	Value string
+}
+
+// Name returns the part of the tag's value before the first comma.
+func (t Tag) Name() string {
+	name, _, _ := strings.Cut(t.Value, ",")
+	return name
+}
+
+// Options returns the comma separated parts of the tag's value after the name, or nil if there are none.
+func (t Tag) Options() []string {
+	_, options, ok := strings.Cut(t.Value, ",")
+	if !ok {
+		return nil
+	}
+	return strings.Split(options, ",")
+}
+
+// HasOption reports whether option is one of the tag's options.
+func (t Tag) HasOption(option string) bool {
+	for _, o := range t.Options() {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the tag with the given key and a boolean indicating if it was found. Like reflect.StructTag.Lookup,
+// it returns the first tag if the key is repeated.
+func (t Tags) Lookup(key string) (Tag, bool) {
+	for _, tag := range t {
+		if tag.Key == key {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
+
+// TagError is returned when a struct tag doesn't follow the conventional format of space separated key:"value"
+// pairs. reflect.StructTag.Get silently ignores the pair it fails at and every pair after it. TagError wraps
+// ErrMalformedTag.
+type TagError struct {
+	// Tag is the malformed struct tag.
+	Tag reflect.StructTag
+	// Offset is the byte offset in Tag of the malformed pair.
+	Offset int
+	// Reason describes what is malformed.
+	Reason string
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("malformed struct tag `%s` at offset %d: %s", e.Tag, e.Offset, e.Reason)
+}
+
+func (e *TagError) Unwrap() error {
+	return ErrMalformedTag
+}
+
+// ParseTag parses every key:"value" pair of the struct tag. It returns a *TagError if the tag is malformed, using the
+// same rules as reflect.StructTag.Lookup and go vet: a key is a nonempty run of characters other than spaces,
+// quotes, colons and control characters, the value is a Go string literal, and pairs are separated by spaces.
+func ParseTag(tag reflect.StructTag) (Tags, error) {
+	var tags Tags
+	s := string(tag)
+	for offset := 0; ; {
+		// Skip leading space.
+		for offset < len(s) && s[offset] == ' ' {
+			offset++
+		}
+		if offset == len(s) {
+			return tags, nil
+		}
+
+		malformed := func(reason string) (Tags, error) {
+			return nil, &TagError{Tag: tag, Offset: offset, Reason: reason}
+		}
+
+		i := offset
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		switch {
+		case i == offset:
+			return malformed("missing key")
+		case i == len(s) || s[i] != ':':
+			return malformed(fmt.Sprintf("key %s is not followed by a colon", s[offset:i]))
+		case i+1 == len(s) || s[i+1] != '"':
+			return malformed(fmt.Sprintf("value of key %s is not quoted", s[offset:i]))
+		}
+		key := s[offset:i]
+
+		// Scan to the closing quote, skipping escaped characters.
+		j := i + 2
+		for j < len(s) && s[j] != '"' {
+			if s[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(s) {
+			return malformed(fmt.Sprintf("value of key %s is missing its closing quote", key))
+		}
+		value, err := strconv.Unquote(s[i+1 : j+1])
+		if err != nil {
+			return malformed(fmt.Sprintf("value of key %s is not a valid string literal", key))
+		}
+		if j+1 < len(s) && s[j+1] != ' ' {
+			return malformed(fmt.Sprintf("value of key %s is not followed by a space", key))
+		}
+
+		tags = append(tags, Tag{Key: key, Value: value})
+		offset = j + 1
+	}
+}
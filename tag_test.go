@@ -0,0 +1,72 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type testUser struct {
+	ID       int    `json:"user_id,omitempty" db:"id"`
+	Name     string `json:"name"`
+	Password string `json:"-"`
+}
+
+func TestParseTag(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tags, err := anyiter.ParseTag(`json:"user_id,omitempty,string"  db:"id" x:"a\"b"`)
+		assert.Nil(t, err)
+		assert.Equal(t, anyiter.Tags{
+			{Key: "json", Value: "user_id,omitempty,string"},
+			{Key: "db", Value: "id"},
+			{Key: "x", Value: `a"b`},
+		}, tags)
+
+		tag, ok := tags.Lookup("json")
+		assert.True(t, ok)
+		assert.Equal(t, "user_id", tag.Name())
+		assert.Equal(t, []string{"omitempty", "string"}, tag.Options())
+		assert.True(t, tag.HasOption("omitempty"))
+		assert.False(t, tag.HasOption("user_id"))
+
+		tag, ok = tags.Lookup("db")
+		assert.True(t, ok)
+		assert.Equal(t, "id", tag.Name())
+		assert.Nil(t, tag.Options())
+
+		_, ok = tags.Lookup("xml")
+		assert.False(t, ok)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		tags, err := anyiter.ParseTag(" ")
+		assert.Nil(t, tags)
+		assert.Nil(t, err)
+	})
+
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		err  string
+	}{
+		{"missing key", `json:"a" :"b"`, "at offset 9: missing key"},
+		{"missing colon", `json "a"`, "at offset 0: key json is not followed by a colon"},
+		{"unquoted", `json:a`, "at offset 0: value of key json is not quoted"},
+		{"missing closing quote", `json:"a`, "at offset 0: value of key json is missing its closing quote"},
+		{"bad escape", `json:"\q"`, "at offset 0: value of key json is not a valid string literal"},
+		{"not separated", `json:"a"db:"b"`, "at offset 0: value of key json is not followed by a space"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := anyiter.ParseTag(test.tag)
+			assert.True(t, errors.Is(err, anyiter.ErrMalformedTag))
+			assert.EqualError(t, err, "malformed struct tag `"+string(test.tag)+"` "+test.err)
+
+			var tagErr *anyiter.TagError
+			assert.True(t, errors.As(err, &tagErr))
+			assert.Equal(t, test.tag, tagErr.Tag)
+		})
+	}
+}
@@ -1,8 +1,9 @@
 package anyiter
 
 import (
-	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 )
 
 // SafeType is a method-for-method recreation of the reflect.Type interface, but with the methods that can panic
@@ -40,6 +41,8 @@ type SafeType interface {
 	//
 	// For an interface type, the returned Method's Type field gives the
 	// method signature, without a receiver, and the Func field is nil.
+	//
+	// It returns nil if the method was not found.
 	MethodByName(string) (SafeMethod, bool)
 
 	// NumMethod returns the number of methods accessible using Method.
@@ -84,8 +87,12 @@ type SafeType interface {
 	// Comparable reports whether values of this type are comparable.
 	Comparable() bool
 
+	// Equal reports whether the type and u wrap the same reflect.Type.
+	// SafeTypes returned by NewSafeType for the same reflect.Type are also ==.
+	Equal(u SafeType) bool
+
 	// Bits returns the size of the type in bits.
-	// It panics if the type's Kind is not one of the
+	// It errors if the type's Kind is not one of the
 	// sized or unsized Int, Uint, Float, or Complex kinds.
 	Bits() (int, error)
 
@@ -118,8 +125,11 @@ type SafeType interface {
 
 	// FieldByIndex returns the nested field corresponding
 	// to the index sequence. It is equivalent to calling Field
-	// successively for each index i.
-	// It errors if the type's Kind is not Struct.
+	// successively for each index i, following a pointer to a
+	// struct, like an embedded *T, before each step but the first.
+	// It errors if the type's Kind is not Struct, if a step reaches
+	// a type that is neither a struct nor a pointer to one, or if an
+	// index is out of range.
 	FieldByIndex(index []int) (reflect.StructField, error)
 
 	// FieldByName returns the struct field with the given name
@@ -140,6 +150,14 @@ type SafeType interface {
 	// structs containing embedded fields.
 	FieldByNameFunc(match func(string) bool) (reflect.StructField, bool)
 
+	// FieldByTag returns the first of the struct type's fields with a tag
+	// for key whose name, the part of the value before any comma, is name.
+	// Fields promoted from embedded structs are not considered.
+	// It errors if the type's Kind is not Struct, returns a *TagError
+	// if any field's tag is malformed, even one after the field with
+	// the tag, and an error wrapping ErrNotFound if no field has the tag.
+	FieldByTag(key, name string) (reflect.StructField, error)
+
 	// In returns the type of a function type's i'th input parameter.
 	// It errors if the type's Kind is not Func.
 	// It errors if i is not in the range [0, NumIn()).
@@ -169,15 +187,48 @@ type SafeType interface {
 	// It errors if the type's Kind is not Func.
 	// It errors if i is not in the range [0, NumOut()).
 	Out(i int) (SafeType, error)
+
+	// AsStruct returns a view of a struct type whose methods don't need to check its Kind.
+	// It errors if the type's Kind is not Struct.
+	AsStruct() (SafeStructType, error)
+
+	// AsFunc returns a view of a function type whose methods don't need to check its Kind.
+	// It errors if the type's Kind is not Func.
+	AsFunc() (SafeFuncType, error)
+
+	// AsMap returns a view of a map type whose methods don't need to check its Kind.
+	// It errors if the type's Kind is not Map.
+	AsMap() (SafeMapType, error)
+
+	// AsChan returns a view of a channel type whose methods don't need to check its Kind.
+	// It errors if the type's Kind is not Chan.
+	AsChan() (SafeChanType, error)
+
+	// AsArray returns a view of an array type whose methods don't need to check its Kind.
+	// It errors if the type's Kind is not Array.
+	AsArray() (SafeArrayType, error)
+
+	// AsPointer returns a view of a pointer type whose methods don't need to check its Kind.
+	// It errors if the type's Kind is not Ptr.
+	AsPointer() (SafePointerType, error)
 }
 
 type safeType struct {
 	reflectType reflect.Type
 }
 
-// NewSafeType wraps an existing reflect.Type in the SafeType interface
+// safeTypes interns the SafeTypes returned by NewSafeType, keyed by their reflect.Type.
+var safeTypes sync.Map
+
+// NewSafeType wraps an existing reflect.Type in the SafeType interface.
+// It returns the same SafeType for every call with the same reflect.Type, so SafeTypes can be compared with == and
+// used as map keys.
 func NewSafeType(reflectType reflect.Type) SafeType {
-	return &safeType{reflectType: reflectType}
+	if cached, ok := safeTypes.Load(reflectType); ok {
+		return cached.(*safeType)
+	}
+	cached, _ := safeTypes.LoadOrStore(reflectType, &safeType{reflectType: reflectType})
+	return cached.(*safeType)
 }
 
 func (s safeType) ReflectType() reflect.Type {
@@ -193,8 +244,8 @@ func (s safeType) FieldAlign() int {
 }
 
 func (s safeType) Method(i int) (SafeMethod, error) {
-	if i > s.reflectType.NumMethod() {
-		return nil, errors.New("index out of range")
+	if err := checkIndex("SafeType.Method", i, s.reflectType.NumMethod()); err != nil {
+		return nil, err
 	}
 
 	return NewSafeMethod(s.reflectType.Method(i)), nil
@@ -202,7 +253,10 @@ func (s safeType) Method(i int) (SafeMethod, error) {
 
 func (s safeType) MethodByName(s2 string) (SafeMethod, bool) {
 	m, ok := s.reflectType.MethodByName(s2)
-	return NewSafeMethod(m), ok
+	if !ok {
+		return nil, false
+	}
+	return NewSafeMethod(m), true
 }
 
 func (s safeType) NumMethod() int {
@@ -245,63 +299,73 @@ func (s safeType) Comparable() bool {
 	return s.reflectType.Comparable()
 }
 
+func (s safeType) Equal(u SafeType) bool {
+	return u != nil && s.reflectType == u.ReflectType()
+}
+
 func (s safeType) Bits() (int, error) {
-	switch s.reflectType.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
-		 reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
-		return s.reflectType.Bits(), nil
+	err := s.checkKind("SafeType.Bits", reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32,
+		reflect.Float64, reflect.Complex64, reflect.Complex128)
+	if err != nil {
+		return 0, err
 	}
-	return 0, errors.New("not a Int*, Uint*, Float* or Complex*")
+	return s.reflectType.Bits(), nil
 }
 
 func (s safeType) ChanDir() (reflect.ChanDir, error) {
-	if s.reflectType.Kind() != reflect.Chan {
-		return reflect.ChanDir(0), errors.New("type is not Chan")
+	if err := s.checkKind("SafeType.ChanDir", reflect.Chan); err != nil {
+		return reflect.ChanDir(0), err
 	}
 	return s.reflectType.ChanDir(), nil
 }
 
 func (s safeType) IsVariadic() (bool, error) {
-	if s.reflectType.Kind() != reflect.Func {
-		return false, errors.New("type is not Func")
+	if err := s.checkKind("SafeType.IsVariadic", reflect.Func); err != nil {
+		return false, err
 	}
 	return s.reflectType.IsVariadic(), nil
 }
 
 func (s safeType) Elem() (SafeType, error) {
-	switch s.reflectType.Kind() {
-	case reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice:
-		return NewSafeType(s.ReflectType().Elem()), nil
+	err := s.checkKind("SafeType.Elem", reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("type is not Array, Chan, Map, Ptr or Slice")
+	return NewSafeType(s.ReflectType().Elem()), nil
 }
 
 func (s safeType) Field(i int) (reflect.StructField, error) {
-	if s.reflectType.Kind() != reflect.Struct {
-		return reflect.StructField{}, errors.New("type is not Struct")
+	if err := s.checkKind("SafeType.Field", reflect.Struct); err != nil {
+		return reflect.StructField{}, err
 	}
 
-	if i > s.reflectType.NumField() {
-		return reflect.StructField{}, errors.New("index out of range")
+	if err := checkIndex("SafeType.Field", i, s.reflectType.NumField()); err != nil {
+		return reflect.StructField{}, err
 	}
 
 	return s.reflectType.Field(i), nil
 }
 
 func (s safeType) FieldByIndex(index []int) (reflect.StructField, error) {
-	if s.reflectType.Kind() != reflect.Struct {
-		return reflect.StructField{}, errors.New("type is not Struct")
+	if err := s.checkKind("SafeType.FieldByIndex", reflect.Struct); err != nil {
+		return reflect.StructField{}, err
 	}
 
 	f := reflect.StructField{}
-	t := s
-	for _, i := range index {
-		field, err := t.Field(i)
-		if err != nil {
+	t := s.reflectType
+	for step, i := range index {
+		if step > 0 && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			t = t.Elem()
+		}
+		if err := checkKind("SafeType.FieldByIndex", t.Kind(), reflect.Struct); err != nil {
+			return reflect.StructField{}, err
+		}
+		if err := checkIndex("SafeType.FieldByIndex", i, t.NumField()); err != nil {
 			return reflect.StructField{}, err
 		}
-		f = field
-		t = safeType{reflectType: field.Type}
+		f = t.Field(i)
+		t = f.Type
 	}
 
 	return f, nil
@@ -321,62 +385,131 @@ func (s safeType) FieldByNameFunc(match func(string) bool) (reflect.StructField,
 	return s.reflectType.FieldByNameFunc(match)
 }
 
+func (s safeType) FieldByTag(key, name string) (reflect.StructField, error) {
+	if err := s.checkKind("SafeType.FieldByTag", reflect.Struct); err != nil {
+		return reflect.StructField{}, err
+	}
+
+	// Every tag is parsed before any is matched, so that a malformed tag is reported whatever the field order.
+	tags := make([]Tags, s.reflectType.NumField())
+	for i := range tags {
+		field := s.reflectType.Field(i)
+		var err error
+		if tags[i], err = ParseTag(field.Tag); err != nil {
+			return reflect.StructField{}, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	for i := range tags {
+		if tag, ok := tags[i].Lookup(key); ok && tag.Name() == name {
+			return s.reflectType.Field(i), nil
+		}
+	}
+	return reflect.StructField{}, fmt.Errorf("field with %s tag %s: %w in %s", key, name, ErrNotFound, s.reflectType)
+}
+
 func (s safeType) In(i int) (SafeType, error) {
-	if s.reflectType.Kind() != reflect.Func {
-		return nil, errors.New("type is not Func")
+	if err := s.checkKind("SafeType.In", reflect.Func); err != nil {
+		return nil, err
 	}
 
-	if i > s.reflectType.NumIn() {
-		return nil, errors.New("index out of range")
+	if err := checkIndex("SafeType.In", i, s.reflectType.NumIn()); err != nil {
+		return nil, err
 	}
 
 	return NewSafeType(s.reflectType.In(i)), nil
 }
 
 func (s safeType) Key() (SafeType, error) {
-	if s.reflectType.Kind() != reflect.Map {
-		return nil, errors.New("type is not Map")
+	if err := s.checkKind("SafeType.Key", reflect.Map); err != nil {
+		return nil, err
 	}
 	return NewSafeType(s.reflectType.Key()), nil
 }
 
 func (s safeType) Len() (int, error) {
-	if s.reflectType.Kind() != reflect.Array {
-		return 0, errors.New("type is not Array")
+	if err := s.checkKind("SafeType.Len", reflect.Array); err != nil {
+		return 0, err
 	}
 	return s.reflectType.Len(), nil
 }
 
 func (s safeType) NumField() (int, error) {
-	if s.reflectType.Kind() != reflect.Struct {
-		return 0, errors.New("type is not Struct")
+	if err := s.checkKind("SafeType.NumField", reflect.Struct); err != nil {
+		return 0, err
 	}
 
 	return s.reflectType.NumField(), nil
 }
 
 func (s safeType) NumIn() (int, error) {
-	if s.reflectType.Kind() != reflect.Func {
-		return 0, errors.New("type is not Func")
+	if err := s.checkKind("SafeType.NumIn", reflect.Func); err != nil {
+		return 0, err
 	}
 	return s.reflectType.NumIn(), nil
 }
 
 func (s safeType) NumOut() (int, error) {
-	if s.reflectType.Kind() != reflect.Func {
-		return 0, errors.New("type is not Func")
+	if err := s.checkKind("SafeType.NumOut", reflect.Func); err != nil {
+		return 0, err
 	}
 	return s.reflectType.NumOut(), nil
 }
 
 func (s safeType) Out(i int) (SafeType, error) {
-	if s.reflectType.Kind() != reflect.Func {
-		return nil, errors.New("type is not Func")
+	if err := s.checkKind("SafeType.Out", reflect.Func); err != nil {
+		return nil, err
 	}
 
-	if i > s.reflectType.NumOut() {
-		return nil, errors.New("index out of range")
+	if err := checkIndex("SafeType.Out", i, s.reflectType.NumOut()); err != nil {
+		return nil, err
 	}
 
 	return NewSafeType(s.reflectType.Out(i)), nil
 }
+
+func (s safeType) AsStruct() (SafeStructType, error) {
+	if err := s.checkKind("SafeType.AsStruct", reflect.Struct); err != nil {
+		return nil, err
+	}
+	return &safeStructType{reflectType: s.reflectType}, nil
+}
+
+func (s safeType) AsFunc() (SafeFuncType, error) {
+	if err := s.checkKind("SafeType.AsFunc", reflect.Func); err != nil {
+		return nil, err
+	}
+	return &safeFuncType{reflectType: s.reflectType}, nil
+}
+
+func (s safeType) AsMap() (SafeMapType, error) {
+	if err := s.checkKind("SafeType.AsMap", reflect.Map); err != nil {
+		return nil, err
+	}
+	return &safeMapType{reflectType: s.reflectType}, nil
+}
+
+func (s safeType) AsChan() (SafeChanType, error) {
+	if err := s.checkKind("SafeType.AsChan", reflect.Chan); err != nil {
+		return nil, err
+	}
+	return &safeChanType{reflectType: s.reflectType}, nil
+}
+
+func (s safeType) AsArray() (SafeArrayType, error) {
+	if err := s.checkKind("SafeType.AsArray", reflect.Array); err != nil {
+		return nil, err
+	}
+	return &safeArrayType{reflectType: s.reflectType}, nil
+}
+
+func (s safeType) AsPointer() (SafePointerType, error) {
+	if err := s.checkKind("SafeType.AsPointer", reflect.Ptr); err != nil {
+		return nil, err
+	}
+	return &safePointerType{reflectType: s.reflectType}, nil
+}
+
+// checkKind returns a *KindError for the operation op if the type's Kind is not one of kinds.
+func (s safeType) checkKind(op string, kinds ...reflect.Kind) error {
+	return checkKind(op, s.reflectType.Kind(), kinds...)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/levisaya/anyiter"
 	"github.com/stretchr/testify/assert"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -46,7 +47,10 @@ func TestSafeType_Method(t *testing.T) {
 	t.Run("out of range", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		_, err := anyiter.NewSafeType(typ).Method(100)
-		assert.Equal(t, errors.New("index out of range"), err)
+		assertIndexError(t, err, "SafeType.Method")
+
+		_, err = anyiter.NewSafeType(typ).Method(typ.NumMethod())
+		assertIndexError(t, err, "SafeType.Method")
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -58,12 +62,22 @@ func TestSafeType_Method(t *testing.T) {
 }
 
 func TestSafeType_MethodByName(t *testing.T) {
-	typ := reflect.TypeOf(testStruct{someField: 5})
+	t.Run("found", func(t *testing.T) {
+		typ := reflect.TypeOf(testStruct{someField: 5})
+
+		_, expectedFound := typ.MethodByName("GetSomeField")
+		method, ok := anyiter.NewSafeType(typ).MethodByName("GetSomeField")
+		assert.NotNil(t, method)
+		assert.Equal(t, expectedFound, ok)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		typ := reflect.TypeOf(testStruct{someField: 5})
 
-	_, expectedFound := typ.MethodByName("GetSomeField")
-	method, ok := anyiter.NewSafeType(typ).MethodByName("GetSomeField")
-	assert.NotNil(t, method)
-	assert.Equal(t, expectedFound, ok)
+		method, ok := anyiter.NewSafeType(typ).MethodByName("Missing")
+		assert.Nil(t, method)
+		assert.False(t, ok)
+	})
 }
 
 func TestSafeType_NumMethod(t *testing.T) {
@@ -100,7 +114,7 @@ func TestSafeType_Kind(t *testing.T) {
 
 func TestSafeType_Implements(t *testing.T) {
 	typ := reflect.TypeOf(testStruct{someField: 5})
-	interfaceType := anyiter.NewSafeType(reflect.TypeOf((*testInterface)(nil)).Elem())
+	interfaceType := anyiter.TypeOf[testInterface]()
 	assert.True(t, anyiter.NewSafeType(typ).Implements(interfaceType))
 }
 
@@ -122,12 +136,48 @@ func TestSafeType_Comparable(t *testing.T) {
 	assert.True(t, safeType.Comparable())
 }
 
+func TestNewSafeType(t *testing.T) {
+	t.Run("interned", func(t *testing.T) {
+		typ := reflect.TypeOf(testStruct{})
+		assert.True(t, anyiter.NewSafeType(typ) == anyiter.NewSafeType(typ))
+		assert.False(t, anyiter.NewSafeType(typ) == anyiter.NewSafeType(reflect.TypeOf(1)))
+
+		registry := map[anyiter.SafeType]string{anyiter.NewSafeType(typ): "testStruct"}
+		assert.Equal(t, "testStruct", registry[anyiter.NewSafeType(reflect.TypeOf(testStruct{}))])
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		type concurrent struct{}
+		typ := reflect.TypeOf(concurrent{})
+		results := make([]anyiter.SafeType, 8)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = anyiter.NewSafeType(typ)
+			}()
+		}
+		wg.Wait()
+		for _, result := range results {
+			assert.True(t, result == results[0])
+		}
+	})
+}
+
+func TestSafeType_Equal(t *testing.T) {
+	safeType := anyiter.NewSafeType(reflect.TypeOf(testStruct{}))
+	assert.True(t, safeType.Equal(anyiter.NewSafeType(reflect.TypeOf(testStruct{}))))
+	assert.False(t, safeType.Equal(anyiter.NewSafeType(reflect.TypeOf(secondTestStruct{}))))
+	assert.False(t, safeType.Equal(nil))
+}
+
 func TestSafeType_Bits(t *testing.T) {
 	t.Run("invalid type", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Bits()
-		assert.Equal(t, errors.New("not a Int*, Uint*, Float* or Complex*"), err)
+		assert.True(t, errors.Is(err, anyiter.ErrKindMismatch))
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -144,7 +194,7 @@ func TestSafeType_ChanDir(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.ChanDir()
-		assert.Equal(t, errors.New("type is not Chan"), err)
+		assertKindError(t, err, "SafeType.ChanDir", reflect.Chan)
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -161,7 +211,7 @@ func TestSafeType_IsVariadic(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.IsVariadic()
-		assert.Equal(t, errors.New("type is not Func"), err)
+		assertKindError(t, err, "SafeType.IsVariadic", reflect.Func)
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -178,7 +228,7 @@ func TestSafeType_Elem(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Elem()
-		assert.Equal(t, errors.New("type is not Array, Chan, Map, Ptr or Slice"), err)
+		assertKindError(t, err, "SafeType.Elem", reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice)
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -195,14 +245,14 @@ func TestSafeType_Field(t *testing.T) {
 		typ := reflect.TypeOf(1)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Field(0)
-		assert.Equal(t, errors.New("type is not Struct"), err)
+		assertKindError(t, err, "SafeType.Field", reflect.Struct)
 	})
 
 	t.Run("out of range", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Field(500)
-		assert.Equal(t, errors.New("index out of range"), err)
+		assertIndexError(t, err, "SafeType.Field")
 	})
 
 	t.Run("valid", func(t *testing.T) {
@@ -219,14 +269,14 @@ func TestSafeType_FieldByIndex(t *testing.T) {
 		typ := reflect.TypeOf(1)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.FieldByIndex([]int{0})
-		assert.Equal(t, errors.New("type is not Struct"), err)
+		assertKindError(t, err, "SafeType.FieldByIndex", reflect.Struct)
 	})
 
 	t.Run("out of range", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.FieldByIndex([]int{500})
-		assert.Equal(t, errors.New("index out of range"), err)
+		assertIndexError(t, err, "SafeType.FieldByIndex")
 	})
 
 	t.Run("valid", func(t *testing.T) {
@@ -236,6 +286,17 @@ func TestSafeType_FieldByIndex(t *testing.T) {
 		assert.NotNil(t, field)
 		assert.Nil(t, err)
 	})
+
+	t.Run("embedded pointer", func(t *testing.T) {
+		field, err := anyiter.TypeOf[testEmbedding]().FieldByIndex([]int{2, 1})
+		assert.Nil(t, err)
+		assert.Equal(t, "Owner", field.Name)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := anyiter.TypeOf[testEmbedding]().FieldByIndex([]int{1, 0, 0})
+		assertKindError(t, err, "SafeType.FieldByIndex", reflect.Struct)
+	})
 }
 
 func TestSafeType_FieldByName(t *testing.T) {
@@ -276,19 +337,58 @@ func TestSafeType_FieldByNameFunc(t *testing.T) {
 	})
 }
 
+func TestSafeType_FieldByTag(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.TypeOf[int]().FieldByTag("json", "user_id")
+		assertKindError(t, err, "SafeType.FieldByTag", reflect.Struct)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		field, err := anyiter.TypeOf[testUser]().FieldByTag("json", "user_id")
+		assert.Nil(t, err)
+		assert.Equal(t, "ID", field.Name)
+
+		field, err = anyiter.TypeOf[testUser]().FieldByTag("db", "id")
+		assert.Nil(t, err)
+		assert.Equal(t, "ID", field.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := anyiter.TypeOf[testUser]().FieldByTag("json", "id")
+		assert.True(t, errors.Is(err, anyiter.ErrNotFound))
+		assert.EqualError(t, err, "field with json tag id: not found in anyiter_test.testUser")
+	})
+
+	t.Run("malformed tag", func(t *testing.T) {
+		malformed, err := anyiter.StructOf([]reflect.StructField{
+			{Name: "Name", Type: reflect.TypeOf(""), Tag: `json:"name"`},
+			{Name: "ID", Type: reflect.TypeOf(0), Tag: "json:user_id"},
+		})
+		assert.Nil(t, err)
+		_, err = malformed.FieldByTag("json", "user_id")
+		assert.True(t, errors.Is(err, anyiter.ErrMalformedTag))
+		assert.EqualError(t, err,
+			"field ID: malformed struct tag `json:user_id` at offset 0: value of key json is not quoted")
+
+		// The malformed tag is reported even if the field with the tag comes before it.
+		_, err = malformed.FieldByTag("json", "name")
+		assert.True(t, errors.Is(err, anyiter.ErrMalformedTag))
+	})
+}
+
 func TestSafeType_In(t *testing.T) {
 	t.Run("invalid type", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.In(1)
-		assert.Equal(t, errors.New("type is not Func"), err)
+		assertKindError(t, err, "SafeType.In", reflect.Func)
 	})
 
 	t.Run("out of range", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5}.AddToField)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.In(500)
-		assert.Equal(t, errors.New("index out of range"), err)
+		assertIndexError(t, err, "SafeType.In")
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -305,14 +405,14 @@ func TestSafeType_Key(t *testing.T) {
 		typ := reflect.TypeOf(1)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Key()
-		assert.Equal(t, errors.New("type is not Map"), err)
+		assertKindError(t, err, "SafeType.Key", reflect.Map)
 	})
 
 	t.Run("valid", func(t *testing.T) {
 		typ := reflect.TypeOf(map[string]string{})
 		safeType := anyiter.NewSafeType(typ)
 		retType, err := safeType.Key()
-		assert.Equal(t, anyiter.NewSafeType(reflect.TypeOf("")), retType)
+		assert.True(t, anyiter.NewSafeType(reflect.TypeOf("")) == retType)
 		assert.Nil(t, err)
 	})
 }
@@ -322,7 +422,7 @@ func TestSafeType_Len(t *testing.T) {
 		typ := reflect.TypeOf(1)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Len()
-		assert.Equal(t, errors.New("type is not Array"), err)
+		assertKindError(t, err, "SafeType.Len", reflect.Array)
 	})
 
 	t.Run("valid", func(t *testing.T) {
@@ -340,7 +440,7 @@ func TestSafeType_NumField(t *testing.T) {
 		typ := reflect.TypeOf(1)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.NumField()
-		assert.Equal(t, errors.New("type is not Struct"), err)
+		assertKindError(t, err, "SafeType.NumField", reflect.Struct)
 	})
 
 	t.Run("valid", func(t *testing.T) {
@@ -357,7 +457,7 @@ func TestSafeType_NumIn(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.NumIn()
-		assert.Equal(t, errors.New("type is not Func"), err)
+		assertKindError(t, err, "SafeType.NumIn", reflect.Func)
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -374,7 +474,7 @@ func TestSafeType_NumOut(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.NumOut()
-		assert.Equal(t, errors.New("type is not Func"), err)
+		assertKindError(t, err, "SafeType.NumOut", reflect.Func)
 	})
 
 	t.Run("valid type", func(t *testing.T) {
@@ -391,14 +491,14 @@ func TestSafeType_Out(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5})
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Out(0)
-		assert.Equal(t, errors.New("type is not Func"), err)
+		assertKindError(t, err, "SafeType.Out", reflect.Func)
 	})
 
 	t.Run("index out of range", func(t *testing.T) {
 		typ := reflect.TypeOf(testStruct{someField: 5}.GetSomeField)
 		safeType := anyiter.NewSafeType(typ)
 		_, err := safeType.Out(500)
-		assert.Equal(t, errors.New("index out of range"), err)
+		assertIndexError(t, err, "SafeType.Out")
 	})
 
 	t.Run("valid", func(t *testing.T) {
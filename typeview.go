@@ -0,0 +1,259 @@
+package anyiter
+
+import "reflect"
+
+// The views below are narrow wrappers for a reflect.Type of a single Kind, returned by the As methods of SafeType.
+// Having checked the Kind once, their methods can't fail: lookups that can miss, like a field index out of range,
+// return a boolean instead of an error.
+
+// SafeStructType is a view of a struct type.
+type SafeStructType interface {
+	// Type returns the struct type as a SafeType.
+	Type() SafeType
+
+	// NumField returns the struct type's field count.
+	NumField() int
+
+	// Field returns the struct type's i'th field and a boolean indicating if i is in the range [0, NumField()).
+	Field(i int) (reflect.StructField, bool)
+
+	// Fields returns the struct type's fields, in order.
+	Fields() []reflect.StructField
+
+	// FieldByName returns the struct field with the given name
+	// and a boolean indicating if the field was found.
+	FieldByName(name string) (reflect.StructField, bool)
+
+	// FieldByNameFunc returns the struct field with a name that satisfies the match function and a boolean
+	// indicating if the field was found. It follows the same rules as SafeType.FieldByNameFunc.
+	FieldByNameFunc(match func(string) bool) (reflect.StructField, bool)
+
+	// AllFields returns every field reachable from the struct type, including those promoted through embedded
+	// structs and embedded pointers to structs, ordered by depth and then by index path. Each entry flags whether
+	// Go's selector rules shadow the field or make it ambiguous.
+	AllFields() []FieldEntry
+}
+
+// SafeFuncType is a view of a function type.
+type SafeFuncType interface {
+	// Type returns the function type as a SafeType.
+	Type() SafeType
+
+	// NumIn returns the function type's input parameter count.
+	NumIn() int
+
+	// In returns the type of the function type's i'th input parameter and a boolean indicating if i is in the range
+	// [0, NumIn()).
+	In(i int) (SafeType, bool)
+
+	// Ins returns the types of the function type's input parameters, in order.
+	Ins() []SafeType
+
+	// NumOut returns the function type's output parameter count.
+	NumOut() int
+
+	// Out returns the type of the function type's i'th output parameter and a boolean indicating if i is in the
+	// range [0, NumOut()).
+	Out(i int) (SafeType, bool)
+
+	// Outs returns the types of the function type's output parameters, in order.
+	Outs() []SafeType
+
+	// IsVariadic reports whether the function type's final input parameter is a "..." parameter. If so, its type is
+	// the implicit []T.
+	IsVariadic() bool
+}
+
+// SafeMapType is a view of a map type.
+type SafeMapType interface {
+	// Type returns the map type as a SafeType.
+	Type() SafeType
+
+	// Key returns the map type's key type.
+	Key() SafeType
+
+	// Elem returns the map type's element type.
+	Elem() SafeType
+}
+
+// SafeChanType is a view of a channel type.
+type SafeChanType interface {
+	// Type returns the channel type as a SafeType.
+	Type() SafeType
+
+	// Elem returns the channel type's element type.
+	Elem() SafeType
+
+	// ChanDir returns the channel type's direction.
+	ChanDir() reflect.ChanDir
+}
+
+// SafeArrayType is a view of an array type.
+type SafeArrayType interface {
+	// Type returns the array type as a SafeType.
+	Type() SafeType
+
+	// Elem returns the array type's element type.
+	Elem() SafeType
+
+	// Len returns the array type's length.
+	Len() int
+}
+
+// SafePointerType is a view of a pointer type.
+type SafePointerType interface {
+	// Type returns the pointer type as a SafeType.
+	Type() SafeType
+
+	// Elem returns the type the pointer type points to.
+	Elem() SafeType
+}
+
+type safeStructType struct {
+	reflectType reflect.Type
+}
+
+func (s safeStructType) Type() SafeType {
+	return NewSafeType(s.reflectType)
+}
+
+func (s safeStructType) NumField() int {
+	return s.reflectType.NumField()
+}
+
+func (s safeStructType) Field(i int) (reflect.StructField, bool) {
+	if i < 0 || i >= s.reflectType.NumField() {
+		return reflect.StructField{}, false
+	}
+	return s.reflectType.Field(i), true
+}
+
+func (s safeStructType) Fields() []reflect.StructField {
+	fields := make([]reflect.StructField, s.reflectType.NumField())
+	for i := range fields {
+		fields[i] = s.reflectType.Field(i)
+	}
+	return fields
+}
+
+func (s safeStructType) FieldByName(name string) (reflect.StructField, bool) {
+	return s.reflectType.FieldByName(name)
+}
+
+func (s safeStructType) FieldByNameFunc(match func(string) bool) (reflect.StructField, bool) {
+	return s.reflectType.FieldByNameFunc(match)
+}
+
+func (s safeStructType) AllFields() []FieldEntry {
+	return allFields(s.reflectType)
+}
+
+type safeFuncType struct {
+	reflectType reflect.Type
+}
+
+func (s safeFuncType) Type() SafeType {
+	return NewSafeType(s.reflectType)
+}
+
+func (s safeFuncType) NumIn() int {
+	return s.reflectType.NumIn()
+}
+
+func (s safeFuncType) In(i int) (SafeType, bool) {
+	if i < 0 || i >= s.reflectType.NumIn() {
+		return nil, false
+	}
+	return NewSafeType(s.reflectType.In(i)), true
+}
+
+func (s safeFuncType) Ins() []SafeType {
+	ins := make([]SafeType, s.reflectType.NumIn())
+	for i := range ins {
+		ins[i] = NewSafeType(s.reflectType.In(i))
+	}
+	return ins
+}
+
+func (s safeFuncType) NumOut() int {
+	return s.reflectType.NumOut()
+}
+
+func (s safeFuncType) Out(i int) (SafeType, bool) {
+	if i < 0 || i >= s.reflectType.NumOut() {
+		return nil, false
+	}
+	return NewSafeType(s.reflectType.Out(i)), true
+}
+
+func (s safeFuncType) Outs() []SafeType {
+	outs := make([]SafeType, s.reflectType.NumOut())
+	for i := range outs {
+		outs[i] = NewSafeType(s.reflectType.Out(i))
+	}
+	return outs
+}
+
+func (s safeFuncType) IsVariadic() bool {
+	return s.reflectType.IsVariadic()
+}
+
+type safeMapType struct {
+	reflectType reflect.Type
+}
+
+func (s safeMapType) Type() SafeType {
+	return NewSafeType(s.reflectType)
+}
+
+func (s safeMapType) Key() SafeType {
+	return NewSafeType(s.reflectType.Key())
+}
+
+func (s safeMapType) Elem() SafeType {
+	return NewSafeType(s.reflectType.Elem())
+}
+
+type safeChanType struct {
+	reflectType reflect.Type
+}
+
+func (s safeChanType) Type() SafeType {
+	return NewSafeType(s.reflectType)
+}
+
+func (s safeChanType) Elem() SafeType {
+	return NewSafeType(s.reflectType.Elem())
+}
+
+func (s safeChanType) ChanDir() reflect.ChanDir {
+	return s.reflectType.ChanDir()
+}
+
+type safeArrayType struct {
+	reflectType reflect.Type
+}
+
+func (s safeArrayType) Type() SafeType {
+	return NewSafeType(s.reflectType)
+}
+
+func (s safeArrayType) Elem() SafeType {
+	return NewSafeType(s.reflectType.Elem())
+}
+
+func (s safeArrayType) Len() int {
+	return s.reflectType.Len()
+}
+
+type safePointerType struct {
+	reflectType reflect.Type
+}
+
+func (s safePointerType) Type() SafeType {
+	return NewSafeType(s.reflectType)
+}
+
+func (s safePointerType) Elem() SafeType {
+	return NewSafeType(s.reflectType.Elem())
+}
@@ -0,0 +1,146 @@
+package anyiter_test
+
+import (
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestSafeType_AsStruct(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeType(reflect.TypeOf(1)).AsStruct()
+		assertKindError(t, err, "SafeType.AsStruct", reflect.Struct)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ := reflect.TypeOf(testAccount{})
+		st, err := anyiter.NewSafeType(typ).AsStruct()
+		assert.Nil(t, err)
+		assert.Equal(t, typ, st.Type().ReflectType())
+		assert.Equal(t, typ.NumField(), st.NumField())
+
+		field, ok := st.Field(0)
+		assert.True(t, ok)
+		assert.Equal(t, typ.Field(0), field)
+		_, ok = st.Field(typ.NumField())
+		assert.False(t, ok)
+		_, ok = st.Field(-1)
+		assert.False(t, ok)
+
+		assert.Len(t, st.Fields(), typ.NumField())
+		assert.Equal(t, typ.Field(1), st.Fields()[1])
+
+		field, ok = st.FieldByName("Orders")
+		assert.True(t, ok)
+		assert.Equal(t, "Orders", field.Name)
+
+		field, ok = st.FieldByNameFunc(func(name string) bool { return name == "Orders" })
+		assert.True(t, ok)
+		assert.Equal(t, "Orders", field.Name)
+	})
+}
+
+func TestSafeType_AsFunc(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeType(reflect.TypeOf(1)).AsFunc()
+		assertKindError(t, err, "SafeType.AsFunc", reflect.Func)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ := reflect.TypeOf(func(string, ...int) (bool, error) { return false, nil })
+		ft, err := anyiter.NewSafeType(typ).AsFunc()
+		assert.Nil(t, err)
+		assert.Equal(t, typ, ft.Type().ReflectType())
+		assert.True(t, ft.IsVariadic())
+
+		assert.Equal(t, 2, ft.NumIn())
+		in, ok := ft.In(1)
+		assert.True(t, ok)
+		assert.Equal(t, reflect.TypeOf([]int{}), in.ReflectType())
+		_, ok = ft.In(2)
+		assert.False(t, ok)
+
+		var ins []string
+		for _, in := range ft.Ins() {
+			ins = append(ins, in.String())
+		}
+		assert.Equal(t, []string{"string", "[]int"}, ins)
+
+		assert.Equal(t, 2, ft.NumOut())
+		out, ok := ft.Out(0)
+		assert.True(t, ok)
+		assert.Equal(t, "bool", out.String())
+		_, ok = ft.Out(-1)
+		assert.False(t, ok)
+
+		var outs []string
+		for _, out := range ft.Outs() {
+			outs = append(outs, out.String())
+		}
+		assert.Equal(t, []string{"bool", "error"}, outs)
+	})
+}
+
+func TestSafeType_AsMap(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeType(reflect.TypeOf(1)).AsMap()
+		assertKindError(t, err, "SafeType.AsMap", reflect.Map)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ := reflect.TypeOf(map[string]int{})
+		mt, err := anyiter.NewSafeType(typ).AsMap()
+		assert.Nil(t, err)
+		assert.Equal(t, typ, mt.Type().ReflectType())
+		assert.Equal(t, "string", mt.Key().String())
+		assert.Equal(t, "int", mt.Elem().String())
+	})
+}
+
+func TestSafeType_AsChan(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeType(reflect.TypeOf(1)).AsChan()
+		assertKindError(t, err, "SafeType.AsChan", reflect.Chan)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ := reflect.TypeOf(make(<-chan int))
+		ct, err := anyiter.NewSafeType(typ).AsChan()
+		assert.Nil(t, err)
+		assert.Equal(t, typ, ct.Type().ReflectType())
+		assert.Equal(t, "int", ct.Elem().String())
+		assert.Equal(t, reflect.RecvDir, ct.ChanDir())
+	})
+}
+
+func TestSafeType_AsArray(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeType(reflect.TypeOf([]int{})).AsArray()
+		assertKindError(t, err, "SafeType.AsArray", reflect.Array)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ := reflect.TypeOf([3]string{})
+		at, err := anyiter.NewSafeType(typ).AsArray()
+		assert.Nil(t, err)
+		assert.Equal(t, typ, at.Type().ReflectType())
+		assert.Equal(t, "string", at.Elem().String())
+		assert.Equal(t, 3, at.Len())
+	})
+}
+
+func TestSafeType_AsPointer(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeType(reflect.TypeOf(1)).AsPointer()
+		assertKindError(t, err, "SafeType.AsPointer", reflect.Ptr)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ := reflect.TypeOf(&testStruct{})
+		pt, err := anyiter.NewSafeType(typ).AsPointer()
+		assert.Nil(t, err)
+		assert.Equal(t, typ, pt.Type().ReflectType())
+		assert.Equal(t, reflect.TypeOf(testStruct{}), pt.Elem().ReflectType())
+	})
+}
@@ -1,6 +1,1183 @@
 package anyiter
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
 // SafeValue is an interface wrapper for the methods of reflect.Value, but with the methods that can panic
 // modified to return errors instead in the cases where they would panic.
 type SafeValue interface {
+	// ReflectValue returns the underlying reflect.Value
+	ReflectValue() reflect.Value
+
+	// Addr returns a pointer value representing the address of v.
+	// It errors if CanAddr() returns false.
+	// Addr is typically used to obtain a pointer to a struct field
+	// or slice element in order to call a method that requires a
+	// pointer receiver.
+	Addr() (SafeValue, error)
+
+	// Bool returns v's underlying value.
+	// It errors if v's kind is not Bool.
+	Bool() (bool, error)
+
+	// Bytes returns v's underlying value.
+	// It errors if v's underlying value is not a slice of bytes.
+	Bytes() ([]byte, error)
+
+	// Call calls the function v with the input arguments in.
+	// For example, if len(in) == 3, v.Call(in) represents the Go call v(in[0], in[1], in[2]).
+	// It errors if v's Kind is not Func, if v is a nil func, if the number of
+	// arguments does not match the function's signature or if an argument is
+	// not assignable to its parameter type.
+	// It returns the output results as SafeValues.
+	// As in Go, each input argument must be assignable to the
+	// type of the function's corresponding input parameter.
+	// If v is a variadic function, Call creates the variadic slice parameter
+	// itself, copying in the corresponding values.
+	Call(in []SafeValue) ([]SafeValue, error)
+
+	// CallSlice calls the variadic function v with the input arguments in,
+	// assigning the slice in[len(in)-1] to v's final variadic argument.
+	// For example, if len(in) == 3, v.CallSlice(in) represents the Go call v(in[0], in[1], in[2]...).
+	// It errors if v's Kind is not Func, if v is not variadic, if v is a nil
+	// func or if the arguments do not match the function's signature.
+	// It returns the output results as SafeValues.
+	CallSlice(in []SafeValue) ([]SafeValue, error)
+
+	// CanAddr reports whether the value's address can be obtained with Addr.
+	// Such values are called addressable. A value is addressable if it is
+	// an element of a slice, an element of an addressable array,
+	// a field of an addressable struct, or the result of dereferencing a pointer.
+	// If CanAddr returns false, calling Addr will error.
+	CanAddr() bool
+
+	// CanInterface reports whether Interface can be used without erroring.
+	// It returns false for the invalid value and for values obtained
+	// through unexported struct fields.
+	CanInterface() bool
+
+	// CanSet reports whether the value of v can be changed.
+	// A Value can be changed only if it is addressable and was not
+	// obtained by the use of unexported struct fields.
+	// If CanSet returns false, calling Set or any type-specific
+	// setter (e.g., SetBool, SetInt) will error.
+	CanSet() bool
+
+	// Cap returns v's capacity.
+	// It errors if v's Kind is not Array, Chan, or Slice.
+	Cap() (int, error)
+
+	// Close closes the channel v.
+	// It errors if v's Kind is not Chan, if v is receive-only, nil or
+	// already closed.
+	Close() error
+
+	// Complex returns v's underlying value, as a complex128.
+	// It errors if v's Kind is not Complex64 or Complex128
+	Complex() (complex128, error)
+
+	// Convert returns the value v converted to type t.
+	// If the usual Go conversion rules do not allow conversion
+	// of the value v to type t, Convert errors.
+	Convert(t SafeType) (SafeValue, error)
+
+	// Elem returns the value that the interface v contains
+	// or that the pointer v points to.
+	// It errors if v's Kind is not Interface or Ptr.
+	// It returns the invalid SafeValue if v is nil.
+	Elem() (SafeValue, error)
+
+	// Field returns the i'th field of the struct v.
+	// It errors if v's Kind is not Struct or i is out of range.
+	Field(i int) (SafeValue, error)
+
+	// FieldByIndex returns the nested field corresponding to index.
+	// It is equivalent to calling Field successively for each index i,
+	// following a pointer to a struct, like an embedded *T, before each
+	// step but the first.
+	// It errors if v's Kind is not Struct, if a step reaches a value that
+	// is neither a struct nor a pointer to one, or if an index is out of
+	// range. It returns a *NilPointerError if a pointer to follow is nil.
+	FieldByIndex(index []int) (SafeValue, error)
+
+	// FieldByName returns the struct field with the given name
+	// and a boolean indicating if the field was found.
+	// A field promoted through a nil embedded pointer is not found;
+	// use FieldByIndex with the field's index to get a *NilPointerError.
+	FieldByName(name string) (SafeValue, bool)
+
+	// FieldByNameFunc returns the struct field with a name
+	// that satisfies the match function
+	// and a boolean indicating if the field was found.
+	// A field promoted through a nil embedded pointer is not found;
+	// use FieldByIndex with the field's index to get a *NilPointerError.
+	FieldByNameFunc(match func(string) bool) (SafeValue, bool)
+
+	// Float returns v's underlying value, as a float64.
+	// It errors if v's Kind is not Float32 or Float64
+	Float() (float64, error)
+
+	// Index returns v's i'th element.
+	// It errors if v's Kind is not Array, Slice, or String or i is out of range.
+	Index(i int) (SafeValue, error)
+
+	// Int returns v's underlying value, as an int64.
+	// It errors if v's Kind is not Int, Int8, Int16, Int32, or Int64.
+	Int() (int64, error)
+
+	// Interface returns v's current value as an any.
+	// It is equivalent to:
+	//	var i any = (v's underlying value)
+	// It errors if the SafeValue is invalid or was obtained by accessing
+	// unexported struct fields.
+	Interface() (any, error)
+
+	// IsNil reports whether its argument v is nil. The argument must be
+	// a chan, func, interface, map, pointer, or slice value; if it is
+	// not, IsNil errors.
+	IsNil() (bool, error)
+
+	// IsValid reports whether v represents a value.
+	// It returns false if v is the zero reflect.Value.
+	IsValid() bool
+
+	// IsZero reports whether v is the zero value for its type.
+	// It errors if the argument is invalid.
+	IsZero() (bool, error)
+
+	// Kind returns v's Kind.
+	// If v is the zero reflect.Value (IsValid returns false), Kind returns Invalid.
+	Kind() reflect.Kind
+
+	// Len returns v's length.
+	// It errors if v's Kind is not Array, Chan, Map, Slice, or String.
+	Len() (int, error)
+
+	// MapIndex returns the value associated with key in the map v.
+	// It errors if v's Kind is not Map or if key is not assignable to
+	// the map's key type.
+	// It returns the invalid SafeValue if key is not found in the map or if v represents a nil map.
+	MapIndex(key SafeValue) (SafeValue, error)
+
+	// MapKeys returns a slice containing all the keys present in the map,
+	// in unspecified order.
+	// It errors if v's Kind is not Map.
+	// It returns an empty slice if v represents a nil map.
+	MapKeys() ([]SafeValue, error)
+
+	// MapRange returns a range iterator for a map.
+	// It errors if v's Kind is not Map.
+	MapRange() (SafeMapIter, error)
+
+	// Method returns a function value corresponding to v's i'th method.
+	// The arguments to a Call on the returned function should not include
+	// a receiver; the returned function will always use v as the receiver.
+	// Method errors if i is out of range or if v is a nil interface value.
+	// It returns ErrUnexported for an unexported method of an interface
+	// value, which can't be called.
+	Method(i int) (SafeValue, error)
+
+	// MethodByName returns a function value corresponding to the method
+	// of v with the given name and a boolean indicating if the method was found.
+	// An unexported method of an interface value is not found.
+	MethodByName(name string) (SafeValue, bool)
+
+	// NumField returns the number of fields in the struct v.
+	// It errors if v's Kind is not Struct.
+	NumField() (int, error)
+
+	// NumMethod returns the number of exported methods in the value's method set.
+	// It errors if v is invalid.
+	NumMethod() (int, error)
+
+	// OverflowComplex reports whether the complex128 x cannot be represented by v's type.
+	// It errors if v's Kind is not Complex64 or Complex128.
+	OverflowComplex(x complex128) (bool, error)
+
+	// OverflowFloat reports whether the float64 x cannot be represented by v's type.
+	// It errors if v's Kind is not Float32 or Float64.
+	OverflowFloat(x float64) (bool, error)
+
+	// OverflowInt reports whether the int64 x cannot be represented by v's type.
+	// It errors if v's Kind is not Int, Int8, Int16, Int32, or Int64.
+	OverflowInt(x int64) (bool, error)
+
+	// OverflowUint reports whether the uint64 x cannot be represented by v's type.
+	// It errors if v's Kind is not Uint, Uintptr, Uint8, Uint16, Uint32, or Uint64.
+	OverflowUint(x uint64) (bool, error)
+
+	// Pointer returns v's value as a uintptr.
+	// It errors if v's Kind is not Chan, Func, Map, Ptr, Slice, or UnsafePointer.
+	Pointer() (uintptr, error)
+
+	// Recv receives and returns a value from the channel v.
+	// It errors if v's Kind is not Chan or if v is send-only.
+	// The receive blocks until a value is ready.
+	// The boolean value ok is true if the value x corresponds to a send
+	// on the channel, false if it is a zero value received because the channel is closed.
+	Recv() (SafeValue, bool, error)
+
+	// Send sends x on the channel v.
+	// It errors if v's kind is not Chan, if v is receive-only or closed,
+	// or if x is not assignable to v's element type.
+	Send(x SafeValue) error
+
+	// Set assigns x to the value v.
+	// It errors if CanSet returns false.
+	// As in Go, x's value must be assignable to v's type.
+	Set(x SafeValue) error
+
+	// SetBool sets v's underlying value.
+	// It errors if v's Kind is not Bool or if CanSet() is false.
+	SetBool(x bool) error
+
+	// SetBytes sets v's underlying value.
+	// It errors if v's underlying value is not a slice of bytes or if CanSet() is false.
+	SetBytes(x []byte) error
+
+	// SetCap sets v's capacity to n.
+	// It errors if v's Kind is not Slice, if CanSet() is false or if n is
+	// smaller than the length or greater than the capacity of the slice.
+	SetCap(n int) error
+
+	// SetComplex sets v's underlying value to x.
+	// It errors if v's Kind is not Complex64 or Complex128, or if CanSet() is false.
+	SetComplex(x complex128) error
+
+	// SetFloat sets v's underlying value to x.
+	// It errors if v's Kind is not Float32 or Float64, or if CanSet() is false.
+	SetFloat(x float64) error
+
+	// SetInt sets v's underlying value to x.
+	// It errors if v's Kind is not Int, Int8, Int16, Int32, or Int64, or if CanSet() is false.
+	SetInt(x int64) error
+
+	// SetLen sets v's length to n.
+	// It errors if v's Kind is not Slice, if CanSet() is false or if n is
+	// negative or greater than the capacity of the slice.
+	SetLen(n int) error
+
+	// SetMapIndex sets the element associated with key in the map v to elem.
+	// It errors if v's Kind is not Map, if v is a nil map and elem is valid,
+	// or if key or elem are not assignable to the map's key and element types.
+	// If elem is the invalid SafeValue, SetMapIndex deletes the key from the map.
+	SetMapIndex(key, elem SafeValue) error
+
+	// SetPointer sets the unsafe.Pointer value v to x.
+	// It errors if v's Kind is not UnsafePointer or if CanSet() is false.
+	SetPointer(x unsafe.Pointer) error
+
+	// SetString sets v's underlying value to x.
+	// It errors if v's Kind is not String or if CanSet() is false.
+	SetString(x string) error
+
+	// SetUint sets v's underlying value to x.
+	// It errors if v's Kind is not Uint, Uintptr, Uint8, Uint16, Uint32, or Uint64, or if CanSet() is false.
+	SetUint(x uint64) error
+
+	// Slice returns v[i:j].
+	// It errors if v's Kind is not Array, Slice or String, or if v is an unaddressable array,
+	// or if the indexes are out of bounds.
+	Slice(i, j int) (SafeValue, error)
+
+	// Slice3 is the 3-index form of the slice operation: it returns v[i:j:k].
+	// It errors if v's Kind is not Array or Slice, or if v is an unaddressable array,
+	// or if the indexes are out of bounds.
+	Slice3(i, j, k int) (SafeValue, error)
+
+	// String returns the string v's underlying value, as a string.
+	// Unlike the other getters, it does not error if v's Kind is not String.
+	// Instead, it returns a string of the form "<T value>" where T is v's type.
+	String() string
+
+	// TryRecv attempts to receive a value from the channel v but will not block.
+	// It errors if v's Kind is not Chan or if v is send-only.
+	// If the receive delivers a value, x is the transferred value and ok is true.
+	// If the receive cannot finish without blocking, x is the invalid SafeValue and ok is false.
+	// If the channel is closed, x is the zero value for the channel's element type and ok is false.
+	TryRecv() (SafeValue, bool, error)
+
+	// TrySend attempts to send x on the channel v but will not block.
+	// It errors if v's Kind is not Chan, if v is receive-only or closed,
+	// or if x is not assignable to v's element type.
+	// It reports whether the value was sent.
+	TrySend(x SafeValue) (bool, error)
+
+	// Type returns v's type.
+	// It errors if v is invalid.
+	Type() (SafeType, error)
+
+	// Uint returns v's underlying value, as a uint64.
+	// It errors if v's Kind is not Uint, Uintptr, Uint8, Uint16, Uint32, or Uint64.
+	Uint() (uint64, error)
+
+	// UnsafeAddr returns a pointer to v's data.
+	// It errors if v is not addressable.
+	UnsafeAddr() (uintptr, error)
+
+	// AsSlice returns a view of a slice whose methods don't need to check its Kind.
+	// It errors if v's Kind is not Slice.
+	AsSlice() (SafeSliceValue, error)
+
+	// AsMap returns a view of a map whose methods don't need to check its Kind.
+	// It errors if v's Kind is not Map.
+	AsMap() (SafeMapValue, error)
+
+	// AsStruct returns a view of a struct whose methods don't need to check its Kind.
+	// It errors if v's Kind is not Struct.
+	AsStruct() (SafeStructValue, error)
+
+	// AsChan returns a view of a channel whose methods don't need to check its Kind.
+	// It errors if v's Kind is not Chan.
+	AsChan() (SafeChanValue, error)
+
+	// AsFunc returns v as a SafeFunc.
+	// It errors if v's Kind is not Func or if v was obtained using an unexported field.
+	AsFunc() (SafeFunc, error)
+}
+
+// SafeMapIter is an interface wrapper for reflect.MapIter, with Key and Value modified to return errors
+// instead of panicking when the iterator is not positioned on an entry.
+type SafeMapIter interface {
+	// Next advances the map iterator and reports whether there is another
+	// entry. It returns false when the iterator is exhausted; subsequent
+	// calls to Key and Value will error.
+	Next() bool
+
+	// Key returns the key of the iterator's current map entry.
+	// It errors if Next has not been called or the iterator is exhausted.
+	Key() (SafeValue, error)
+
+	// Value returns the value of the iterator's current map entry.
+	// It errors if Next has not been called or the iterator is exhausted.
+	Value() (SafeValue, error)
+}
+
+type safeValue struct {
+	value reflect.Value
+}
+
+// NewSafeValue wraps an existing reflect.Value in the SafeValue interface
+func NewSafeValue(reflectValue reflect.Value) SafeValue {
+	return &safeValue{value: reflectValue}
+}
+
+// reflectValueOf unwraps a SafeValue argument, treating a nil interface as the invalid value.
+func reflectValueOf(v SafeValue) reflect.Value {
+	if v == nil {
+		return reflect.Value{}
+	}
+	return v.ReflectValue()
+}
+
+// wrapValues wraps each reflect.Value in a SafeValue.
+func wrapValues(values []reflect.Value) []SafeValue {
+	wrapped := make([]SafeValue, len(values))
+	for i, v := range values {
+		wrapped[i] = NewSafeValue(v)
+	}
+	return wrapped
+}
+
+// catchPanic runs f, turning a runtime panic into an error. It is only used for the few
+// failures that can't be checked up front, like sending on a closed channel.
+func catchPanic(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	f()
+	return nil
+}
+
+// The kinds of the sized and unsized integers, floats and complex numbers.
+var (
+	intKinds  = []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64}
+	uintKinds = []reflect.Kind{reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64}
+	floatKinds   = []reflect.Kind{reflect.Float32, reflect.Float64}
+	complexKinds = []reflect.Kind{reflect.Complex64, reflect.Complex128}
+)
+
+// checkKind returns a *KindError for the operation op if v's Kind is not one of kinds.
+func (s safeValue) checkKind(op string, kinds ...reflect.Kind) error {
+	return checkKind(op, s.value.Kind(), kinds...)
+}
+
+func (s safeValue) ReflectValue() reflect.Value {
+	return s.value
+}
+
+func (s safeValue) Addr() (SafeValue, error) {
+	if !s.value.CanAddr() {
+		return nil, errors.New("value is not addressable")
+	}
+	return NewSafeValue(s.value.Addr()), nil
+}
+
+func (s safeValue) Bool() (bool, error) {
+	if err := s.checkKind("SafeValue.Bool", reflect.Bool); err != nil {
+		return false, err
+	}
+	return s.value.Bool(), nil
+}
+
+func (s safeValue) Bytes() ([]byte, error) {
+	if s.value.Kind() != reflect.Slice || s.value.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, fmt.Errorf("SafeValue.Bytes: %w: value is not a slice of bytes", ErrKindMismatch)
+	}
+	return s.value.Bytes(), nil
+}
+
+func (s safeValue) Call(in []SafeValue) ([]SafeValue, error) {
+	if err := s.checkKind("SafeValue.Call", reflect.Func); err != nil {
+		return nil, err
+	}
+	fn, err := NewSafeFunc(s.value)
+	if err != nil {
+		return nil, err
+	}
+	return fn.Call(in)
+}
+
+func (s safeValue) CallSlice(in []SafeValue) ([]SafeValue, error) {
+	if err := s.checkKind("SafeValue.CallSlice", reflect.Func); err != nil {
+		return nil, err
+	}
+	fn, err := NewSafeFunc(s.value)
+	if err != nil {
+		return nil, err
+	}
+	return fn.CallSlice(in)
+}
+
+func (s safeValue) CanAddr() bool {
+	return s.value.CanAddr()
+}
+
+func (s safeValue) CanInterface() bool {
+	return s.value.IsValid() && s.value.CanInterface()
+}
+
+func (s safeValue) CanSet() bool {
+	return s.value.CanSet()
+}
+
+func (s safeValue) Cap() (int, error) {
+	if err := s.checkKind("SafeValue.Cap", reflect.Array, reflect.Chan, reflect.Slice); err != nil {
+		return 0, err
+	}
+	return s.value.Cap(), nil
+}
+
+func (s safeValue) Close() error {
+	if err := s.checkKind("SafeValue.Close", reflect.Chan); err != nil {
+		return err
+	}
+	if !s.value.CanInterface() {
+		return ErrUnexported
+	}
+	if s.value.Type().ChanDir()&reflect.SendDir == 0 {
+		return errors.New("channel is receive-only")
+	}
+	if s.value.IsNil() {
+		return errors.New("channel is nil")
+	}
+	return catchPanic(s.value.Close)
+}
+
+func (s safeValue) Complex() (complex128, error) {
+	if err := s.checkKind("SafeValue.Complex", complexKinds...); err != nil {
+		return 0, err
+	}
+	return s.value.Complex(), nil
+}
+
+func (s safeValue) Convert(t SafeType) (SafeValue, error) {
+	if !s.value.IsValid() {
+		return nil, errors.New("value is invalid")
+	}
+	if t == nil || t.ReflectType() == nil {
+		return nil, errors.New("type is nil")
+	}
+
+	target := t.ReflectType()
+	if !s.value.Type().ConvertibleTo(target) {
+		return nil, errors.New("value is not convertible to type")
+	}
+
+	// Slice to array and slice to array pointer conversions are allowed by the type system, but panic when the
+	// slice is shorter than the array.
+	if s.value.Kind() == reflect.Slice {
+		arrayType := target
+		if arrayType.Kind() == reflect.Ptr {
+			arrayType = arrayType.Elem()
+		}
+		if arrayType.Kind() == reflect.Array && s.value.Len() < arrayType.Len() {
+			return nil, errors.New("slice is shorter than array")
+		}
+	}
+
+	return NewSafeValue(s.value.Convert(target)), nil
+}
+
+func (s safeValue) Elem() (SafeValue, error) {
+	if err := s.checkKind("SafeValue.Elem", reflect.Interface, reflect.Ptr); err != nil {
+		return nil, err
+	}
+	return NewSafeValue(s.value.Elem()), nil
+}
+
+func (s safeValue) Field(i int) (SafeValue, error) {
+	if err := s.checkKind("SafeValue.Field", reflect.Struct); err != nil {
+		return nil, err
+	}
+
+	if err := checkIndex("SafeValue.Field", i, s.value.NumField()); err != nil {
+		return nil, err
+	}
+
+	return NewSafeValue(s.value.Field(i)), nil
+}
+
+func (s safeValue) FieldByIndex(index []int) (SafeValue, error) {
+	if err := s.checkKind("SafeValue.FieldByIndex", reflect.Struct); err != nil {
+		return nil, err
+	}
+
+	v := s.value
+	var f reflect.StructField
+	for step, i := range index {
+		if step > 0 && v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+			if v.IsNil() {
+				return nil, &NilPointerError{Op: "SafeValue.FieldByIndex", Field: f, Index: append([]int(nil), index[:step]...)}
+			}
+			v = v.Elem()
+		}
+		if err := checkKind("SafeValue.FieldByIndex", v.Kind(), reflect.Struct); err != nil {
+			return nil, err
+		}
+		if err := checkIndex("SafeValue.FieldByIndex", i, v.NumField()); err != nil {
+			return nil, err
+		}
+		f = v.Type().Field(i)
+		v = v.Field(i)
+	}
+
+	return NewSafeValue(v), nil
+}
+
+func (s safeValue) FieldByName(name string) (SafeValue, bool) {
+	if s.value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	f, ok := s.value.Type().FieldByName(name)
+	if !ok {
+		return nil, false
+	}
+
+	field, err := s.FieldByIndex(f.Index)
+	if err != nil {
+		return nil, false
+	}
+	return field, true
+}
+
+func (s safeValue) FieldByNameFunc(match func(string) bool) (SafeValue, bool) {
+	if s.value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	f, ok := s.value.Type().FieldByNameFunc(match)
+	if !ok {
+		return nil, false
+	}
+
+	field, err := s.FieldByIndex(f.Index)
+	if err != nil {
+		return nil, false
+	}
+	return field, true
+}
+
+func (s safeValue) Float() (float64, error) {
+	if err := s.checkKind("SafeValue.Float", floatKinds...); err != nil {
+		return 0, err
+	}
+	return s.value.Float(), nil
+}
+
+func (s safeValue) Index(i int) (SafeValue, error) {
+	if err := s.checkKind("SafeValue.Index", reflect.Array, reflect.Slice, reflect.String); err != nil {
+		return nil, err
+	}
+
+	if err := checkIndex("SafeValue.Index", i, s.value.Len()); err != nil {
+		return nil, err
+	}
+
+	return NewSafeValue(s.value.Index(i)), nil
+}
+
+func (s safeValue) Int() (int64, error) {
+	if err := s.checkKind("SafeValue.Int", intKinds...); err != nil {
+		return 0, err
+	}
+	return s.value.Int(), nil
+}
+
+func (s safeValue) Interface() (any, error) {
+	if !s.value.IsValid() {
+		return nil, errors.New("value is invalid")
+	}
+	if !s.value.CanInterface() {
+		return nil, ErrUnexported
+	}
+	return s.value.Interface(), nil
+}
+
+func (s safeValue) IsNil() (bool, error) {
+	if err := s.checkKind("SafeValue.IsNil", reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr,
+		reflect.Slice, reflect.UnsafePointer); err != nil {
+		return false, err
+	}
+	return s.value.IsNil(), nil
+}
+
+func (s safeValue) IsValid() bool {
+	return s.value.IsValid()
+}
+
+func (s safeValue) IsZero() (bool, error) {
+	if !s.value.IsValid() {
+		return false, errors.New("value is invalid")
+	}
+	return s.value.IsZero(), nil
+}
+
+func (s safeValue) Kind() reflect.Kind {
+	return s.value.Kind()
+}
+
+func (s safeValue) Len() (int, error) {
+	err := s.checkKind("SafeValue.Len", reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String)
+	if err != nil {
+		return 0, err
+	}
+	return s.value.Len(), nil
+}
+
+func (s safeValue) MapIndex(key SafeValue) (SafeValue, error) {
+	if err := s.checkKind("SafeValue.MapIndex", reflect.Map); err != nil {
+		return nil, err
+	}
+
+	k := reflectValueOf(key)
+	if !k.IsValid() {
+		return nil, errors.New("key is invalid")
+	}
+	if !k.CanInterface() {
+		return nil, errors.New("key was obtained using an unexported field")
+	}
+	if !k.Type().AssignableTo(s.value.Type().Key()) {
+		return nil, errors.New("key is not assignable to map key type")
+	}
+	if !k.Comparable() {
+		return nil, errors.New("key is not comparable")
+	}
+
+	return NewSafeValue(s.value.MapIndex(k)), nil
+}
+
+func (s safeValue) MapKeys() ([]SafeValue, error) {
+	if err := s.checkKind("SafeValue.MapKeys", reflect.Map); err != nil {
+		return nil, err
+	}
+	return wrapValues(s.value.MapKeys()), nil
+}
+
+func (s safeValue) MapRange() (SafeMapIter, error) {
+	if err := s.checkKind("SafeValue.MapRange", reflect.Map); err != nil {
+		return nil, err
+	}
+	return &safeMapIter{iter: s.value.MapRange()}, nil
+}
+
+func (s safeValue) Method(i int) (SafeValue, error) {
+	if !s.value.IsValid() {
+		return nil, errors.New("value is invalid")
+	}
+
+	if err := checkIndex("SafeValue.Method", i, s.value.NumMethod()); err != nil {
+		return nil, err
+	}
+
+	if s.value.Kind() == reflect.Interface {
+		if s.value.IsNil() {
+			return nil, errors.New("interface is nil")
+		}
+		if s.value.Type().Method(i).PkgPath != "" {
+			return nil, ErrUnexported
+		}
+	}
+
+	return NewSafeValue(s.value.Method(i)), nil
+}
+
+func (s safeValue) MethodByName(name string) (SafeValue, bool) {
+	if !s.value.IsValid() {
+		return nil, false
+	}
+
+	if s.value.Kind() == reflect.Interface {
+		if s.value.IsNil() {
+			return nil, false
+		}
+		if m, ok := s.value.Type().MethodByName(name); ok && m.PkgPath != "" {
+			return nil, false
+		}
+	}
+
+	m := s.value.MethodByName(name)
+	if !m.IsValid() {
+		return nil, false
+	}
+	return NewSafeValue(m), true
+}
+
+func (s safeValue) NumField() (int, error) {
+	if err := s.checkKind("SafeValue.NumField", reflect.Struct); err != nil {
+		return 0, err
+	}
+	return s.value.NumField(), nil
+}
+
+func (s safeValue) NumMethod() (int, error) {
+	if !s.value.IsValid() {
+		return 0, errors.New("value is invalid")
+	}
+	return s.value.NumMethod(), nil
+}
+
+func (s safeValue) OverflowComplex(x complex128) (bool, error) {
+	if err := s.checkKind("SafeValue.OverflowComplex", complexKinds...); err != nil {
+		return false, err
+	}
+	return s.value.OverflowComplex(x), nil
+}
+
+func (s safeValue) OverflowFloat(x float64) (bool, error) {
+	if err := s.checkKind("SafeValue.OverflowFloat", floatKinds...); err != nil {
+		return false, err
+	}
+	return s.value.OverflowFloat(x), nil
+}
+
+func (s safeValue) OverflowInt(x int64) (bool, error) {
+	if err := s.checkKind("SafeValue.OverflowInt", intKinds...); err != nil {
+		return false, err
+	}
+	return s.value.OverflowInt(x), nil
+}
+
+func (s safeValue) OverflowUint(x uint64) (bool, error) {
+	if err := s.checkKind("SafeValue.OverflowUint", uintKinds...); err != nil {
+		return false, err
+	}
+	return s.value.OverflowUint(x), nil
+}
+
+func (s safeValue) Pointer() (uintptr, error) {
+	if err := s.checkKind("SafeValue.Pointer", reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.Slice,
+		reflect.UnsafePointer); err != nil {
+		return 0, err
+	}
+	return s.value.Pointer(), nil
+}
+
+func (s safeValue) Recv() (SafeValue, bool, error) {
+	if err := s.checkRecv("SafeValue.Recv"); err != nil {
+		return nil, false, err
+	}
+	x, ok := s.value.Recv()
+	return NewSafeValue(x), ok, nil
+}
+
+func (s safeValue) checkRecv(op string) error {
+	if err := s.checkKind(op, reflect.Chan); err != nil {
+		return err
+	}
+	if !s.value.CanInterface() {
+		return ErrUnexported
+	}
+	if s.value.Type().ChanDir()&reflect.RecvDir == 0 {
+		return errors.New("channel is send-only")
+	}
+	return nil
+}
+
+func (s safeValue) Send(x SafeValue) error {
+	v, err := s.checkSend("SafeValue.Send", x)
+	if err != nil {
+		return err
+	}
+	return catchPanic(func() { s.value.Send(v) })
+}
+
+func (s safeValue) checkSend(op string, x SafeValue) (reflect.Value, error) {
+	if err := s.checkKind(op, reflect.Chan); err != nil {
+		return reflect.Value{}, err
+	}
+	if !s.value.CanInterface() {
+		return reflect.Value{}, ErrUnexported
+	}
+	if s.value.Type().ChanDir()&reflect.SendDir == 0 {
+		return reflect.Value{}, errors.New("channel is receive-only")
+	}
+
+	v := reflectValueOf(x)
+	if !v.IsValid() {
+		return reflect.Value{}, errors.New("value to send is invalid")
+	}
+	if !v.CanInterface() {
+		return reflect.Value{}, errors.New("value to send was obtained using an unexported field")
+	}
+	if !v.Type().AssignableTo(s.value.Type().Elem()) {
+		return reflect.Value{}, errors.New("value to send is not assignable to channel element type")
+	}
+	return v, nil
+}
+
+func (s safeValue) Set(x SafeValue) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+
+	v := reflectValueOf(x)
+	if !v.IsValid() {
+		return errors.New("value to set is invalid")
+	}
+	if !v.CanInterface() {
+		return errors.New("value to set was obtained using an unexported field")
+	}
+	if !v.Type().AssignableTo(s.value.Type()) {
+		return errors.New("value to set is not assignable to type")
+	}
+
+	s.value.Set(v)
+	return nil
+}
+
+func (s safeValue) SetBool(x bool) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetBool", reflect.Bool); err != nil {
+		return err
+	}
+	s.value.SetBool(x)
+	return nil
+}
+
+func (s safeValue) SetBytes(x []byte) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if s.value.Kind() != reflect.Slice || s.value.Type().Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("SafeValue.SetBytes: %w: value is not a slice of bytes", ErrKindMismatch)
+	}
+	s.value.SetBytes(x)
+	return nil
+}
+
+func (s safeValue) SetCap(n int) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetCap", reflect.Slice); err != nil {
+		return err
+	}
+	if err := checkBounds("SafeValue.SetCap", s.value.Cap(), s.value.Len(), n); err != nil {
+		return err
+	}
+	s.value.SetCap(n)
+	return nil
+}
+
+func (s safeValue) SetComplex(x complex128) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetComplex", complexKinds...); err != nil {
+		return err
+	}
+	s.value.SetComplex(x)
+	return nil
+}
+
+func (s safeValue) SetFloat(x float64) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetFloat", floatKinds...); err != nil {
+		return err
+	}
+	s.value.SetFloat(x)
+	return nil
+}
+
+func (s safeValue) SetInt(x int64) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetInt", intKinds...); err != nil {
+		return err
+	}
+	s.value.SetInt(x)
+	return nil
+}
+
+func (s safeValue) SetLen(n int) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetLen", reflect.Slice); err != nil {
+		return err
+	}
+	if err := checkBounds("SafeValue.SetLen", s.value.Cap(), n); err != nil {
+		return err
+	}
+	s.value.SetLen(n)
+	return nil
+}
+
+func (s safeValue) SetMapIndex(key, elem SafeValue) error {
+	if err := s.checkKind("SafeValue.SetMapIndex", reflect.Map); err != nil {
+		return err
+	}
+	if !s.value.CanInterface() {
+		return ErrUnexported
+	}
+
+	mapType := s.value.Type()
+	k := reflectValueOf(key)
+	if !k.IsValid() {
+		return errors.New("key is invalid")
+	}
+	if !k.CanInterface() {
+		return errors.New("key was obtained using an unexported field")
+	}
+	if !k.Type().AssignableTo(mapType.Key()) {
+		return errors.New("key is not assignable to map key type")
+	}
+	if !k.Comparable() {
+		return errors.New("key is not comparable")
+	}
+
+	e := reflectValueOf(elem)
+	if e.IsValid() {
+		if !e.CanInterface() {
+			return errors.New("element was obtained using an unexported field")
+		}
+		if !e.Type().AssignableTo(mapType.Elem()) {
+			return errors.New("element is not assignable to map element type")
+		}
+		if s.value.IsNil() {
+			return errors.New("map is nil")
+		}
+	}
+
+	s.value.SetMapIndex(k, e)
+	return nil
+}
+
+func (s safeValue) SetPointer(x unsafe.Pointer) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetPointer", reflect.UnsafePointer); err != nil {
+		return err
+	}
+	s.value.SetPointer(x)
+	return nil
+}
+
+func (s safeValue) SetString(x string) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetString", reflect.String); err != nil {
+		return err
+	}
+	s.value.SetString(x)
+	return nil
+}
+
+func (s safeValue) SetUint(x uint64) error {
+	if !s.value.CanSet() {
+		return ErrNotSettable
+	}
+	if err := s.checkKind("SafeValue.SetUint", uintKinds...); err != nil {
+		return err
+	}
+	s.value.SetUint(x)
+	return nil
+}
+
+func (s safeValue) Slice(i, j int) (SafeValue, error) {
+	var limit int
+	switch s.value.Kind() {
+	case reflect.Array:
+		if !s.value.CanAddr() {
+			return nil, errors.New("array is not addressable")
+		}
+		limit = s.value.Len()
+	case reflect.Slice:
+		limit = s.value.Cap()
+	case reflect.String:
+		limit = s.value.Len()
+	default:
+		return nil, s.checkKind("SafeValue.Slice", reflect.Array, reflect.Slice, reflect.String)
+	}
+
+	if err := checkBounds("SafeValue.Slice", limit, i, j); err != nil {
+		return nil, err
+	}
+
+	return NewSafeValue(s.value.Slice(i, j)), nil
+}
+
+func (s safeValue) Slice3(i, j, k int) (SafeValue, error) {
+	var limit int
+	switch s.value.Kind() {
+	case reflect.Array:
+		if !s.value.CanAddr() {
+			return nil, errors.New("array is not addressable")
+		}
+		limit = s.value.Len()
+	case reflect.Slice:
+		limit = s.value.Cap()
+	default:
+		return nil, s.checkKind("SafeValue.Slice3", reflect.Array, reflect.Slice)
+	}
+
+	if err := checkBounds("SafeValue.Slice3", limit, i, j, k); err != nil {
+		return nil, err
+	}
+
+	return NewSafeValue(s.value.Slice3(i, j, k)), nil
+}
+
+func (s safeValue) String() string {
+	return s.value.String()
+}
+
+func (s safeValue) TryRecv() (SafeValue, bool, error) {
+	if err := s.checkRecv("SafeValue.TryRecv"); err != nil {
+		return nil, false, err
+	}
+	x, ok := s.value.TryRecv()
+	return NewSafeValue(x), ok, nil
+}
+
+func (s safeValue) TrySend(x SafeValue) (bool, error) {
+	v, err := s.checkSend("SafeValue.TrySend", x)
+	if err != nil {
+		return false, err
+	}
+
+	var sent bool
+	err = catchPanic(func() { sent = s.value.TrySend(v) })
+	return sent, err
+}
+
+func (s safeValue) Type() (SafeType, error) {
+	if !s.value.IsValid() {
+		return nil, errors.New("value is invalid")
+	}
+	return NewSafeType(s.value.Type()), nil
+}
+
+func (s safeValue) Uint() (uint64, error) {
+	if err := s.checkKind("SafeValue.Uint", uintKinds...); err != nil {
+		return 0, err
+	}
+	return s.value.Uint(), nil
+}
+
+func (s safeValue) UnsafeAddr() (uintptr, error) {
+	if !s.value.CanAddr() {
+		return 0, errors.New("value is not addressable")
+	}
+	return s.value.UnsafeAddr(), nil
+}
+
+func (s safeValue) AsSlice() (SafeSliceValue, error) {
+	if err := s.checkKind("SafeValue.AsSlice", reflect.Slice); err != nil {
+		return nil, err
+	}
+	return &safeSliceValue{value: s.value}, nil
+}
+
+func (s safeValue) AsMap() (SafeMapValue, error) {
+	if err := s.checkKind("SafeValue.AsMap", reflect.Map); err != nil {
+		return nil, err
+	}
+	return &safeMapValue{value: s.value}, nil
+}
+
+func (s safeValue) AsStruct() (SafeStructValue, error) {
+	if err := s.checkKind("SafeValue.AsStruct", reflect.Struct); err != nil {
+		return nil, err
+	}
+	return &safeStructValue{value: s.value}, nil
+}
+
+func (s safeValue) AsChan() (SafeChanValue, error) {
+	if err := s.checkKind("SafeValue.AsChan", reflect.Chan); err != nil {
+		return nil, err
+	}
+	return &safeChanValue{value: s.value}, nil
+}
+
+func (s safeValue) AsFunc() (SafeFunc, error) {
+	if err := s.checkKind("SafeValue.AsFunc", reflect.Func); err != nil {
+		return nil, err
+	}
+	return NewSafeFunc(s.value)
+}
+
+type safeMapIter struct {
+	iter *reflect.MapIter
+	ok   bool
+	done bool
+}
+
+func (s *safeMapIter) Next() bool {
+	if s.done {
+		return false
+	}
+	s.ok = s.iter.Next()
+	s.done = !s.ok
+	return s.ok
+}
+
+func (s *safeMapIter) Key() (SafeValue, error) {
+	if !s.ok {
+		return nil, errors.New("iterator is not positioned on an entry")
+	}
+	return NewSafeValue(s.iter.Key()), nil
+}
+
+func (s *safeMapIter) Value() (SafeValue, error) {
+	if !s.ok {
+		return nil, errors.New("iterator is not positioned on an entry")
+	}
+	return NewSafeValue(s.iter.Value()), nil
 }
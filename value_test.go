@@ -0,0 +1,964 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestSafeValue_ReflectValue(t *testing.T) {
+	val := reflect.ValueOf(1)
+	assert.Equal(t, val, anyiter.NewSafeValue(val).ReflectValue())
+}
+
+func TestSafeValue_Addr(t *testing.T) {
+	t.Run("not addressable", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Addr()
+		assert.Equal(t, errors.New("value is not addressable"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		i := 1
+		addr, err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).Addr()
+		assert.Nil(t, err)
+		assert.Equal(t, &i, addr.ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_Bool(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Bool()
+		assertKindError(t, err, "SafeValue.Bool", reflect.Bool)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		b, err := anyiter.NewSafeValue(reflect.ValueOf(true)).Bool()
+		assert.True(t, b)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Bytes(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1})).Bytes()
+		assert.True(t, errors.Is(err, anyiter.ErrKindMismatch))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		b, err := anyiter.NewSafeValue(reflect.ValueOf([]byte("abc"))).Bytes()
+		assert.Equal(t, []byte("abc"), b)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Call(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	args := func(vals ...any) []anyiter.SafeValue {
+		in := make([]anyiter.SafeValue, len(vals))
+		for i, v := range vals {
+			in[i] = anyiter.NewSafeValue(reflect.ValueOf(v))
+		}
+		return in
+	}
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Call(nil)
+		assertKindError(t, err, "SafeValue.Call", reflect.Func)
+	})
+
+	t.Run("nil func", func(t *testing.T) {
+		var fn func()
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(fn)).Call(nil)
+		assert.Equal(t, errors.New("func is nil"), err)
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(add)).Call(args(1))
+		assert.Equal(t, errors.New("wrong number of arguments for func(int, int) int: got 1, want 2"), err)
+	})
+
+	t.Run("unassignable argument", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(add)).Call(args(1, "2"))
+		assert.Equal(t, errors.New("argument 1: string is not assignable to int"), err)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(add)).Call([]anyiter.SafeValue{nil, nil})
+		assert.Equal(t, errors.New("argument 0 is invalid"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := anyiter.NewSafeValue(reflect.ValueOf(add)).Call(args(1, 2))
+		assert.Nil(t, err)
+		assert.Len(t, out, 1)
+		assert.Equal(t, 3, out[0].ReflectValue().Interface())
+	})
+
+	t.Run("variadic", func(t *testing.T) {
+		ts := testStruct{someField: 5}
+		out, err := anyiter.NewSafeValue(reflect.ValueOf(ts.AddToField)).Call(args(1, 2, 3))
+		assert.Nil(t, err)
+		assert.Len(t, out, 0)
+	})
+}
+
+func TestSafeValue_CallSlice(t *testing.T) {
+	sum := func(vals ...int) int {
+		total := 0
+		for _, val := range vals {
+			total += val
+		}
+		return total
+	}
+
+	t.Run("not variadic", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(func(int) {})).CallSlice(nil)
+		assert.Equal(t, errors.New("func(int) is not variadic"), err)
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(sum)).CallSlice(nil)
+		assert.Equal(t, errors.New("wrong number of arguments for func(...int) int: got 0, want 1"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		in := []anyiter.SafeValue{anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2, 3}))}
+		out, err := anyiter.NewSafeValue(reflect.ValueOf(sum)).CallSlice(in)
+		assert.Nil(t, err)
+		assert.Equal(t, 6, out[0].ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_CanInterface(t *testing.T) {
+	assert.False(t, anyiter.NewSafeValue(reflect.Value{}).CanInterface())
+	assert.True(t, anyiter.NewSafeValue(reflect.ValueOf(1)).CanInterface())
+	assert.False(t, anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5}).Field(0)).CanInterface())
+}
+
+func TestSafeValue_Cap(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Cap()
+		assertKindError(t, err, "SafeValue.Cap", reflect.Array, reflect.Chan, reflect.Slice)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		c, err := anyiter.NewSafeValue(reflect.ValueOf(make([]int, 0, 10))).Cap()
+		assert.Equal(t, 10, c)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Close(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		err := anyiter.NewSafeValue(reflect.ValueOf(1)).Close()
+		assertKindError(t, err, "SafeValue.Close", reflect.Chan)
+	})
+
+	t.Run("receive-only", func(t *testing.T) {
+		var ch <-chan int = make(chan int)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Close()
+		assert.Equal(t, errors.New("channel is receive-only"), err)
+	})
+
+	t.Run("nil channel", func(t *testing.T) {
+		var ch chan int
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Close()
+		assert.Equal(t, errors.New("channel is nil"), err)
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		ch := make(chan int)
+		close(ch)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Close()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ch := make(chan int)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Close()
+		assert.Nil(t, err)
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+}
+
+func TestSafeValue_Complex(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Complex()
+		assertKindError(t, err, "SafeValue.Complex", reflect.Complex64, reflect.Complex128)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		c, err := anyiter.NewSafeValue(reflect.ValueOf(1 + 2i)).Complex()
+		assert.Equal(t, 1+2i, c)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Convert(t *testing.T) {
+	t.Run("not convertible", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Convert(anyiter.NewSafeType(reflect.TypeOf([]int{})))
+		assert.Equal(t, errors.New("value is not convertible to type"), err)
+	})
+
+	t.Run("slice shorter than array", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1})).Convert(anyiter.NewSafeType(reflect.TypeOf(&[4]int{})))
+		assert.Equal(t, errors.New("slice is shorter than array"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		v, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Convert(anyiter.NewSafeType(reflect.TypeOf(int64(0))))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), v.ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_Elem(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Elem()
+		assertKindError(t, err, "SafeValue.Elem", reflect.Interface, reflect.Ptr)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		var p *int
+		elem, err := anyiter.NewSafeValue(reflect.ValueOf(p)).Elem()
+		assert.Nil(t, err)
+		assert.False(t, elem.IsValid())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		elem, err := anyiter.NewSafeValue(reflect.ValueOf(&testStruct{someField: 5})).Elem()
+		assert.Nil(t, err)
+		assert.Equal(t, reflect.Struct, elem.Kind())
+	})
+}
+
+func TestSafeValue_Field(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Field(0)
+		assertKindError(t, err, "SafeValue.Field", reflect.Struct)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).Field(500)
+		assertIndexError(t, err, "SafeValue.Field")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		field, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).Field(0)
+		assert.Nil(t, err)
+		i, err := field.Int()
+		assert.Equal(t, int64(5), i)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_FieldByIndex(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).FieldByIndex([]int{0})
+		assertKindError(t, err, "SafeValue.FieldByIndex", reflect.Struct)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).FieldByIndex([]int{500})
+		assertIndexError(t, err, "SafeValue.FieldByIndex")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		val := reflect.ValueOf(secondTestStruct{testStruct{someField: 5}})
+		field, err := anyiter.NewSafeValue(val).FieldByIndex([]int{0, 0})
+		assert.Nil(t, err)
+		i, err := field.Int()
+		assert.Equal(t, int64(5), i)
+		assert.Nil(t, err)
+	})
+
+	t.Run("embedded pointer", func(t *testing.T) {
+		v := anyiter.ValueOf(testEmbedding{testOther: &testOther{Owner: "acme"}})
+		field, err := v.FieldByIndex([]int{2, 1})
+		assert.Nil(t, err)
+		assert.Equal(t, "acme", field.String())
+
+		field, ok := v.FieldByName("Owner")
+		assert.True(t, ok)
+		assert.Equal(t, "acme", field.String())
+	})
+
+	t.Run("nil embedded pointer", func(t *testing.T) {
+		v := anyiter.ValueOf(testEmbedding{})
+		index := []int{2, 1}
+		_, err := v.FieldByIndex(index)
+		assert.True(t, errors.Is(err, anyiter.ErrNilPointer))
+		assert.EqualError(t, err, "SafeValue.FieldByIndex: field testOther at index [2] is a nil *anyiter_test.testOther")
+
+		var nilErr *anyiter.NilPointerError
+		assert.True(t, errors.As(err, &nilErr))
+		assert.Equal(t, "testOther", nilErr.Field.Name)
+		assert.Equal(t, []int{2}, nilErr.Index)
+		nilErr.Index = append(nilErr.Index, 0)
+		assert.Equal(t, []int{2, 1}, index)
+
+		_, ok := v.FieldByName("Owner")
+		assert.False(t, ok)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := anyiter.ValueOf(testEmbedding{}).FieldByIndex([]int{1, 0, 0})
+		assertKindError(t, err, "SafeValue.FieldByIndex", reflect.Struct)
+	})
+}
+
+func TestSafeValue_FieldByName(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, ok := anyiter.NewSafeValue(reflect.ValueOf(1)).FieldByName("someField")
+		assert.False(t, ok)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, ok := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).FieldByName("otherField")
+		assert.False(t, ok)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		field, ok := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).FieldByName("someField")
+		assert.True(t, ok)
+		assert.Equal(t, reflect.Int, field.Kind())
+	})
+}
+
+func TestSafeValue_FieldByNameFunc(t *testing.T) {
+	nameFunc := func(name string) bool {
+		return name == "someField"
+	}
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, ok := anyiter.NewSafeValue(reflect.ValueOf(1)).FieldByNameFunc(nameFunc)
+		assert.False(t, ok)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		field, ok := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).FieldByNameFunc(nameFunc)
+		assert.True(t, ok)
+		assert.Equal(t, reflect.Int, field.Kind())
+	})
+}
+
+func TestSafeValue_Float(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Float()
+		assertKindError(t, err, "SafeValue.Float", reflect.Float32, reflect.Float64)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		f, err := anyiter.NewSafeValue(reflect.ValueOf(1.5)).Float()
+		assert.Equal(t, 1.5, f)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Index(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Index(0)
+		assertKindError(t, err, "SafeValue.Index", reflect.Array, reflect.Slice, reflect.String)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1})).Index(1)
+		assertIndexError(t, err, "SafeValue.Index")
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1})).Index(-1)
+		assertIndexError(t, err, "SafeValue.Index")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		elem, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2})).Index(1)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, elem.ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_Int(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf("1")).Int()
+		assertKindError(t, err, "SafeValue.Int", reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		i, err := anyiter.NewSafeValue(reflect.ValueOf(int8(3))).Int()
+		assert.Equal(t, int64(3), i)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Interface(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.Value{}).Interface()
+		assert.Equal(t, errors.New("value is invalid"), err)
+	})
+
+	t.Run("unexported field", func(t *testing.T) {
+		field := reflect.ValueOf(testStruct{someField: 5}).Field(0)
+		_, err := anyiter.NewSafeValue(field).Interface()
+		assert.Equal(t, anyiter.ErrUnexported, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		i, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Interface()
+		assert.Equal(t, 1, i)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_IsNil(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).IsNil()
+		assertKindError(t, err, "SafeValue.IsNil", reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		var p *int
+		isNil, err := anyiter.NewSafeValue(reflect.ValueOf(p)).IsNil()
+		assert.True(t, isNil)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_IsValid(t *testing.T) {
+	assert.False(t, anyiter.NewSafeValue(reflect.Value{}).IsValid())
+	assert.True(t, anyiter.NewSafeValue(reflect.ValueOf(1)).IsValid())
+}
+
+func TestSafeValue_IsZero(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.Value{}).IsZero()
+		assert.Equal(t, errors.New("value is invalid"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		isZero, err := anyiter.NewSafeValue(reflect.ValueOf(0)).IsZero()
+		assert.True(t, isZero)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Kind(t *testing.T) {
+	assert.Equal(t, reflect.Invalid, anyiter.NewSafeValue(reflect.Value{}).Kind())
+	assert.Equal(t, reflect.Struct, anyiter.NewSafeValue(reflect.ValueOf(testStruct{})).Kind())
+}
+
+func TestSafeValue_Len(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Len()
+		assertKindError(t, err, "SafeValue.Len", reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		l, err := anyiter.NewSafeValue(reflect.ValueOf(map[string]int{"a": 1, "b": 2})).Len()
+		assert.Equal(t, 2, l)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_MapIndex(t *testing.T) {
+	m := map[string]int{"a": 1}
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).MapIndex(anyiter.NewSafeValue(reflect.ValueOf("a")))
+		assertKindError(t, err, "SafeValue.MapIndex", reflect.Map)
+	})
+
+	t.Run("unassignable key", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(m)).MapIndex(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assert.Equal(t, errors.New("key is not assignable to map key type"), err)
+	})
+
+	t.Run("incomparable key", func(t *testing.T) {
+		key := anyiter.NewSafeValue(reflect.ValueOf([]int{1}))
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(map[any]int{})).MapIndex(key)
+		assert.Equal(t, errors.New("key is not comparable"), err)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		elem, err := anyiter.NewSafeValue(reflect.ValueOf(m)).MapIndex(anyiter.NewSafeValue(reflect.ValueOf("b")))
+		assert.Nil(t, err)
+		assert.False(t, elem.IsValid())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		elem, err := anyiter.NewSafeValue(reflect.ValueOf(m)).MapIndex(anyiter.NewSafeValue(reflect.ValueOf("a")))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, elem.ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_MapKeys(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).MapKeys()
+		assertKindError(t, err, "SafeValue.MapKeys", reflect.Map)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		keys, err := anyiter.NewSafeValue(reflect.ValueOf(map[string]int{"a": 1})).MapKeys()
+		assert.Nil(t, err)
+		assert.Len(t, keys, 1)
+		assert.Equal(t, "a", keys[0].String())
+	})
+}
+
+func TestSafeValue_MapRange(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).MapRange()
+		assertKindError(t, err, "SafeValue.MapRange", reflect.Map)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		iter, err := anyiter.NewSafeValue(reflect.ValueOf(map[string]int{"a": 1})).MapRange()
+		assert.Nil(t, err)
+
+		_, err = iter.Key()
+		assert.Equal(t, errors.New("iterator is not positioned on an entry"), err)
+
+		assert.True(t, iter.Next())
+		key, err := iter.Key()
+		assert.Nil(t, err)
+		assert.Equal(t, "a", key.String())
+		value, err := iter.Value()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, value.ReflectValue().Interface())
+
+		assert.False(t, iter.Next())
+		assert.False(t, iter.Next())
+		_, err = iter.Value()
+		assert.Equal(t, errors.New("iterator is not positioned on an entry"), err)
+	})
+}
+
+type testHiddenImpl struct{}
+
+func (testHiddenImpl) hidden()    {}
+func (testHiddenImpl) Shown() int { return 1 }
+
+func TestSafeValue_Method(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.Value{}).Method(0)
+		assert.Equal(t, errors.New("value is invalid"), err)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).Method(100)
+		assertIndexError(t, err, "SafeValue.Method")
+	})
+
+	t.Run("nil interface", func(t *testing.T) {
+		var i testInterface
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).Method(0)
+		assert.Equal(t, errors.New("interface is nil"), err)
+	})
+
+	t.Run("unexported interface method", func(t *testing.T) {
+		v := anyiter.ValueOf[testHidden](testHiddenImpl{})
+		_, err := v.Method(1)
+		assert.Equal(t, anyiter.ErrUnexported, err)
+
+		method, err := v.Method(0)
+		assert.Nil(t, err)
+		out, err := method.Call(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, out[0].ReflectValue().Interface())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		method, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).Method(1)
+		assert.Nil(t, err)
+		out, err := method.Call(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, out[0].ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_MethodByName(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, ok := anyiter.NewSafeValue(reflect.Value{}).MethodByName("GetSomeField")
+		assert.False(t, ok)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, ok := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).MethodByName("Missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("unexported interface method", func(t *testing.T) {
+		v := anyiter.ValueOf[testHidden](testHiddenImpl{})
+		_, ok := v.MethodByName("hidden")
+		assert.False(t, ok)
+		_, ok = v.MethodByName("Shown")
+		assert.True(t, ok)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		method, ok := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).MethodByName("GetSomeField")
+		assert.True(t, ok)
+		assert.Equal(t, reflect.Func, method.Kind())
+	})
+}
+
+func TestSafeValue_NumField(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).NumField()
+		assertKindError(t, err, "SafeValue.NumField", reflect.Struct)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		n, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).NumField()
+		assert.Equal(t, 1, n)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_NumMethod(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.Value{}).NumMethod()
+		assert.Equal(t, errors.New("value is invalid"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		n, err := anyiter.NewSafeValue(reflect.ValueOf(testStruct{someField: 5})).NumMethod()
+		assert.Equal(t, 2, n)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_OverflowInt(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf("1")).OverflowInt(1)
+		assertKindError(t, err, "SafeValue.OverflowInt", reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		overflows, err := anyiter.NewSafeValue(reflect.ValueOf(int8(1))).OverflowInt(1000)
+		assert.True(t, overflows)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_OverflowUint(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf("1")).OverflowUint(1)
+		assertKindError(t, err, "SafeValue.OverflowUint", reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		overflows, err := anyiter.NewSafeValue(reflect.ValueOf(uint8(1))).OverflowUint(255)
+		assert.False(t, overflows)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Pointer(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Pointer()
+		assertKindError(t, err, "SafeValue.Pointer", reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		p, err := anyiter.NewSafeValue(reflect.ValueOf(&testStruct{})).Pointer()
+		assert.NotEqual(t, uintptr(0), p)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_Recv(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, _, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Recv()
+		assertKindError(t, err, "SafeValue.Recv", reflect.Chan)
+	})
+
+	t.Run("send-only", func(t *testing.T) {
+		var ch chan<- int = make(chan int)
+		_, _, err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Recv()
+		assert.Equal(t, errors.New("channel is send-only"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ch := make(chan int, 1)
+		ch <- 5
+		x, ok, err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Recv()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 5, x.ReflectValue().Interface())
+	})
+}
+
+func TestSafeValue_Send(t *testing.T) {
+	t.Run("receive-only", func(t *testing.T) {
+		var ch <-chan int = make(chan int)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Send(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assert.Equal(t, errors.New("channel is receive-only"), err)
+	})
+
+	t.Run("unassignable", func(t *testing.T) {
+		ch := make(chan int, 1)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Send(anyiter.NewSafeValue(reflect.ValueOf("1")))
+		assert.Equal(t, errors.New("value to send is not assignable to channel element type"), err)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		ch := make(chan int, 1)
+		close(ch)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Send(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ch := make(chan int, 1)
+		err := anyiter.NewSafeValue(reflect.ValueOf(ch)).Send(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, <-ch)
+	})
+}
+
+func TestSafeValue_Set(t *testing.T) {
+	t.Run("not settable", func(t *testing.T) {
+		err := anyiter.NewSafeValue(reflect.ValueOf(1)).Set(anyiter.NewSafeValue(reflect.ValueOf(2)))
+		assert.Equal(t, anyiter.ErrNotSettable, err)
+	})
+
+	t.Run("unassignable", func(t *testing.T) {
+		i := 1
+		err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).Set(anyiter.NewSafeValue(reflect.ValueOf("2")))
+		assert.Equal(t, errors.New("value to set is not assignable to type"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		i := 1
+		err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).Set(anyiter.NewSafeValue(reflect.ValueOf(2)))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, i)
+	})
+}
+
+func TestSafeValue_SetInt(t *testing.T) {
+	t.Run("not settable", func(t *testing.T) {
+		err := anyiter.NewSafeValue(reflect.ValueOf(1)).SetInt(2)
+		assert.Equal(t, anyiter.ErrNotSettable, err)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		s := "1"
+		err := anyiter.NewSafeValue(reflect.ValueOf(&s).Elem()).SetInt(2)
+		assertKindError(t, err, "SafeValue.SetInt", reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		i := 1
+		err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).SetInt(2)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, i)
+	})
+}
+
+func TestSafeValue_SetString(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		i := 1
+		err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).SetString("2")
+		assertKindError(t, err, "SafeValue.SetString", reflect.String)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s := "1"
+		err := anyiter.NewSafeValue(reflect.ValueOf(&s).Elem()).SetString("2")
+		assert.Nil(t, err)
+		assert.Equal(t, "2", s)
+	})
+}
+
+func TestSafeValue_SetLen(t *testing.T) {
+	t.Run("out of range", func(t *testing.T) {
+		s := make([]int, 1, 2)
+		err := anyiter.NewSafeValue(reflect.ValueOf(&s).Elem()).SetLen(3)
+		assertIndexError(t, err, "SafeValue.SetLen")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s := make([]int, 1, 2)
+		err := anyiter.NewSafeValue(reflect.ValueOf(&s).Elem()).SetLen(2)
+		assert.Nil(t, err)
+		assert.Len(t, s, 2)
+	})
+}
+
+func TestSafeValue_SetCap(t *testing.T) {
+	t.Run("out of range", func(t *testing.T) {
+		s := make([]int, 2, 4)
+		err := anyiter.NewSafeValue(reflect.ValueOf(&s).Elem()).SetCap(1)
+		assertIndexError(t, err, "SafeValue.SetCap")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s := make([]int, 2, 4)
+		err := anyiter.NewSafeValue(reflect.ValueOf(&s).Elem()).SetCap(3)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, cap(s))
+	})
+}
+
+func TestSafeValue_SetMapIndex(t *testing.T) {
+	key := anyiter.NewSafeValue(reflect.ValueOf("a"))
+	elem := anyiter.NewSafeValue(reflect.ValueOf(1))
+
+	t.Run("invalid type", func(t *testing.T) {
+		err := anyiter.NewSafeValue(reflect.ValueOf(1)).SetMapIndex(key, elem)
+		assertKindError(t, err, "SafeValue.SetMapIndex", reflect.Map)
+	})
+
+	t.Run("nil map", func(t *testing.T) {
+		var m map[string]int
+		err := anyiter.NewSafeValue(reflect.ValueOf(m)).SetMapIndex(key, elem)
+		assert.Equal(t, errors.New("map is nil"), err)
+	})
+
+	t.Run("unassignable element", func(t *testing.T) {
+		m := map[string]int{}
+		err := anyiter.NewSafeValue(reflect.ValueOf(m)).SetMapIndex(key, key)
+		assert.Equal(t, errors.New("element is not assignable to map element type"), err)
+	})
+
+	t.Run("incomparable key", func(t *testing.T) {
+		m := map[any]int{}
+		err := anyiter.NewSafeValue(reflect.ValueOf(m)).SetMapIndex(anyiter.NewSafeValue(reflect.ValueOf([]int{1})), elem)
+		assert.Equal(t, errors.New("key is not comparable"), err)
+		assert.Empty(t, m)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		m := map[string]int{}
+		err := anyiter.NewSafeValue(reflect.ValueOf(m)).SetMapIndex(key, elem)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"a": 1}, m)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		m := map[string]int{"a": 1}
+		err := anyiter.NewSafeValue(reflect.ValueOf(m)).SetMapIndex(key, nil)
+		assert.Nil(t, err)
+		assert.Empty(t, m)
+	})
+}
+
+func TestSafeValue_Slice(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Slice(0, 1)
+		assertKindError(t, err, "SafeValue.Slice", reflect.Array, reflect.Slice, reflect.String)
+	})
+
+	t.Run("unaddressable array", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([2]int{})).Slice(0, 1)
+		assert.Equal(t, errors.New("array is not addressable"), err)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf("abc")).Slice(2, 5)
+		assertIndexError(t, err, "SafeValue.Slice")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := anyiter.NewSafeValue(reflect.ValueOf("abc")).Slice(1, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, "bc", s.String())
+	})
+}
+
+func TestSafeValue_Slice3(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf("abc")).Slice3(0, 1, 2)
+		assertKindError(t, err, "SafeValue.Slice3", reflect.Array, reflect.Slice)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2})).Slice3(0, 1, 3)
+		assertIndexError(t, err, "SafeValue.Slice3")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := anyiter.NewSafeValue(reflect.ValueOf([]int{1, 2, 3})).Slice3(0, 1, 2)
+		assert.Nil(t, err)
+		c, _ := s.Cap()
+		assert.Equal(t, 2, c)
+	})
+}
+
+func TestSafeValue_String(t *testing.T) {
+	assert.Equal(t, "abc", anyiter.NewSafeValue(reflect.ValueOf("abc")).String())
+	assert.Equal(t, "<int Value>", anyiter.NewSafeValue(reflect.ValueOf(1)).String())
+}
+
+func TestSafeValue_TryRecv(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, _, err := anyiter.NewSafeValue(reflect.ValueOf(1)).TryRecv()
+		assertKindError(t, err, "SafeValue.TryRecv", reflect.Chan)
+	})
+
+	t.Run("would block", func(t *testing.T) {
+		x, ok, err := anyiter.NewSafeValue(reflect.ValueOf(make(chan int))).TryRecv()
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		assert.False(t, x.IsValid())
+	})
+}
+
+func TestSafeValue_TrySend(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).TrySend(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assertKindError(t, err, "SafeValue.TrySend", reflect.Chan)
+	})
+
+	t.Run("would block", func(t *testing.T) {
+		sent, err := anyiter.NewSafeValue(reflect.ValueOf(make(chan int))).TrySend(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assert.Nil(t, err)
+		assert.False(t, sent)
+	})
+}
+
+func TestSafeValue_Type(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.Value{}).Type()
+		assert.Equal(t, errors.New("value is invalid"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		typ, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Type()
+		assert.Nil(t, err)
+		assert.Equal(t, anyiter.NewSafeType(reflect.TypeOf(1)), typ)
+	})
+}
+
+func TestSafeValue_Uint(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).Uint()
+		assertKindError(t, err, "SafeValue.Uint", reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		u, err := anyiter.NewSafeValue(reflect.ValueOf(uint16(7))).Uint()
+		assert.Equal(t, uint64(7), u)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_UnsafeAddr(t *testing.T) {
+	t.Run("not addressable", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).UnsafeAddr()
+		assert.Equal(t, errors.New("value is not addressable"), err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		i := 1
+		addr, err := anyiter.NewSafeValue(reflect.ValueOf(&i).Elem()).UnsafeAddr()
+		assert.NotEqual(t, uintptr(0), addr)
+		assert.Nil(t, err)
+	})
+}
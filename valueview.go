@@ -0,0 +1,263 @@
+package anyiter
+
+import (
+	"errors"
+	"reflect"
+)
+
+// The views below are narrow wrappers for a reflect.Value of a single Kind, returned by the As methods of SafeValue.
+// Having checked the Kind once, their lookups can't fail: a lookup that can miss, like an index out of range,
+// returns a boolean instead of an error. Mutations still return an error, because they depend on more than the Kind,
+// such as whether the value is settable or an argument is assignable.
+
+// SafeSliceValue is a view of a slice.
+type SafeSliceValue interface {
+	// Value returns the slice as a SafeValue.
+	Value() SafeValue
+
+	// Len returns the slice's length.
+	Len() int
+
+	// Cap returns the slice's capacity.
+	Cap() int
+
+	// Index returns the slice's i'th element and a boolean indicating if i is in the range [0, Len()).
+	Index(i int) (SafeValue, bool)
+
+	// Append returns the slice with xs appended, as with Go's append.
+	// It errors if an element of xs is invalid or not assignable to the slice's element type, and returns
+	// ErrUnexported if the slice or an element of xs was obtained using an unexported field.
+	Append(xs ...SafeValue) (SafeSliceValue, error)
+}
+
+// SafeMapValue is a view of a map.
+type SafeMapValue interface {
+	// Value returns the map as a SafeValue.
+	Value() SafeValue
+
+	// Len returns the number of entries in the map.
+	Len() int
+
+	// Keys returns the keys of the map, in unspecified order.
+	Keys() []SafeValue
+
+	// MapIndex returns the element associated with key in the map and a boolean indicating if it was found.
+	// A key that is invalid, not assignable to the map's key type or not comparable is never found.
+	MapIndex(key SafeValue) (SafeValue, bool)
+
+	// SetMapIndex sets the element associated with key in the map to elem.
+	// It errors like SafeValue.SetMapIndex, and also deletes key if elem is the invalid SafeValue.
+	SetMapIndex(key, elem SafeValue) error
+
+	// Delete deletes key from the map.
+	// It errors if key is invalid, not assignable to the map's key type or not comparable.
+	Delete(key SafeValue) error
+
+	// Range returns a range iterator for the map.
+	Range() SafeMapIter
+}
+
+// SafeStructValue is a view of a struct.
+type SafeStructValue interface {
+	// Value returns the struct as a SafeValue.
+	Value() SafeValue
+
+	// NumField returns the number of fields in the struct.
+	NumField() int
+
+	// Field returns the struct's i'th field and a boolean indicating if i is in the range [0, NumField()).
+	Field(i int) (SafeValue, bool)
+
+	// Fields returns the struct's fields, in order.
+	Fields() []SafeValue
+
+	// FieldByName returns the struct field with the given name and a boolean indicating if the field was found.
+	// A field promoted through a nil embedded pointer is not found; use SafeValue.FieldByIndex with the field's index
+	// to get a *NilPointerError.
+	FieldByName(name string) (SafeValue, bool)
+}
+
+// SafeChanValue is a view of a channel.
+type SafeChanValue interface {
+	// Value returns the channel as a SafeValue.
+	Value() SafeValue
+
+	// Len returns the number of elements queued in the channel buffer.
+	Len() int
+
+	// Cap returns the size of the channel buffer.
+	Cap() int
+
+	// Send sends x on the channel.
+	// It errors like SafeValue.Send.
+	Send(x SafeValue) error
+
+	// Recv receives a value from the channel, blocking until one is ready.
+	// It errors like SafeValue.Recv.
+	Recv() (SafeValue, bool, error)
+
+	// TrySend attempts to send x on the channel without blocking, and reports whether it was sent.
+	// It errors like SafeValue.TrySend.
+	TrySend(x SafeValue) (bool, error)
+
+	// TryRecv attempts to receive a value from the channel without blocking.
+	// It errors like SafeValue.TryRecv.
+	TryRecv() (SafeValue, bool, error)
+
+	// Close closes the channel.
+	// It errors like SafeValue.Close.
+	Close() error
+}
+
+type safeSliceValue struct {
+	value reflect.Value
+}
+
+func (s safeSliceValue) Value() SafeValue {
+	return NewSafeValue(s.value)
+}
+
+func (s safeSliceValue) Len() int {
+	return s.value.Len()
+}
+
+func (s safeSliceValue) Cap() int {
+	return s.value.Cap()
+}
+
+func (s safeSliceValue) Index(i int) (SafeValue, bool) {
+	if i < 0 || i >= s.value.Len() {
+		return nil, false
+	}
+	return NewSafeValue(s.value.Index(i)), true
+}
+
+func (s safeSliceValue) Append(xs ...SafeValue) (SafeSliceValue, error) {
+	if !s.value.CanInterface() {
+		return nil, ErrUnexported
+	}
+
+	elems := make([]reflect.Value, len(xs))
+	for i, x := range xs {
+		elems[i] = reflectValueOf(x)
+		if !elems[i].IsValid() {
+			return nil, errors.New("value to append is invalid")
+		}
+		if !elems[i].CanInterface() {
+			return nil, errors.New("value to append was obtained using an unexported field")
+		}
+		if !elems[i].Type().AssignableTo(s.value.Type().Elem()) {
+			return nil, errors.New("value to append is not assignable to slice element type")
+		}
+	}
+	return &safeSliceValue{value: reflect.Append(s.value, elems...)}, nil
+}
+
+type safeMapValue struct {
+	value reflect.Value
+}
+
+func (s safeMapValue) Value() SafeValue {
+	return NewSafeValue(s.value)
+}
+
+func (s safeMapValue) Len() int {
+	return s.value.Len()
+}
+
+func (s safeMapValue) Keys() []SafeValue {
+	return wrapValues(s.value.MapKeys())
+}
+
+func (s safeMapValue) MapIndex(key SafeValue) (SafeValue, bool) {
+	k := reflectValueOf(key)
+	if !k.IsValid() || !k.CanInterface() || !k.Type().AssignableTo(s.value.Type().Key()) ||
+		!k.Comparable() {
+		return nil, false
+	}
+
+	elem := s.value.MapIndex(k)
+	if !elem.IsValid() {
+		return nil, false
+	}
+	return NewSafeValue(elem), true
+}
+
+func (s safeMapValue) SetMapIndex(key, elem SafeValue) error {
+	return safeValue{value: s.value}.SetMapIndex(key, elem)
+}
+
+func (s safeMapValue) Delete(key SafeValue) error {
+	return safeValue{value: s.value}.SetMapIndex(key, nil)
+}
+
+func (s safeMapValue) Range() SafeMapIter {
+	return &safeMapIter{iter: s.value.MapRange()}
+}
+
+type safeStructValue struct {
+	value reflect.Value
+}
+
+func (s safeStructValue) Value() SafeValue {
+	return NewSafeValue(s.value)
+}
+
+func (s safeStructValue) NumField() int {
+	return s.value.NumField()
+}
+
+func (s safeStructValue) Field(i int) (SafeValue, bool) {
+	if i < 0 || i >= s.value.NumField() {
+		return nil, false
+	}
+	return NewSafeValue(s.value.Field(i)), true
+}
+
+func (s safeStructValue) Fields() []SafeValue {
+	fields := make([]SafeValue, s.value.NumField())
+	for i := range fields {
+		fields[i] = NewSafeValue(s.value.Field(i))
+	}
+	return fields
+}
+
+func (s safeStructValue) FieldByName(name string) (SafeValue, bool) {
+	return safeValue{value: s.value}.FieldByName(name)
+}
+
+type safeChanValue struct {
+	value reflect.Value
+}
+
+func (s safeChanValue) Value() SafeValue {
+	return NewSafeValue(s.value)
+}
+
+func (s safeChanValue) Len() int {
+	return s.value.Len()
+}
+
+func (s safeChanValue) Cap() int {
+	return s.value.Cap()
+}
+
+func (s safeChanValue) Send(x SafeValue) error {
+	return safeValue{value: s.value}.Send(x)
+}
+
+func (s safeChanValue) Recv() (SafeValue, bool, error) {
+	return safeValue{value: s.value}.Recv()
+}
+
+func (s safeChanValue) TrySend(x SafeValue) (bool, error) {
+	return safeValue{value: s.value}.TrySend(x)
+}
+
+func (s safeChanValue) TryRecv() (SafeValue, bool, error) {
+	return safeValue{value: s.value}.TryRecv()
+}
+
+func (s safeChanValue) Close() error {
+	return safeValue{value: s.value}.Close()
+}
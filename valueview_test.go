@@ -0,0 +1,196 @@
+package anyiter_test
+
+import (
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestSafeValue_AsSlice(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).AsSlice()
+		assertKindError(t, err, "SafeValue.AsSlice", reflect.Slice)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		sv, err := anyiter.NewSafeValue(reflect.ValueOf(make([]int, 2, 4))).AsSlice()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, sv.Len())
+		assert.Equal(t, 4, sv.Cap())
+		typ, _ := sv.Value().Type()
+		assert.Equal(t, reflect.Slice, typ.Kind())
+
+		elem, ok := sv.Index(1)
+		assert.True(t, ok)
+		i, _ := elem.Int()
+		assert.Equal(t, int64(0), i)
+		_, ok = sv.Index(2)
+		assert.False(t, ok)
+		_, ok = sv.Index(-1)
+		assert.False(t, ok)
+	})
+
+	t.Run("append", func(t *testing.T) {
+		s := []int{1}
+		sv, _ := anyiter.NewSafeValue(reflect.ValueOf(s)).AsSlice()
+		appended, err := sv.Append(safeValues(2, 3)...)
+		assert.Nil(t, err)
+		v, _ := appended.Value().Interface()
+		assert.Equal(t, []int{1, 2, 3}, v)
+		assert.Equal(t, []int{1}, s)
+
+		_, err = sv.Append(safeValues("a")...)
+		assert.EqualError(t, err, "value to append is not assignable to slice element type")
+		_, err = sv.Append(nil)
+		assert.EqualError(t, err, "value to append is invalid")
+	})
+
+	t.Run("append to unexported field", func(t *testing.T) {
+		type holder struct{ items []int }
+		field, _ := anyiter.NewSafeValue(reflect.ValueOf(holder{items: []int{1}})).Field(0)
+		sv, err := field.AsSlice()
+		assert.Nil(t, err)
+		_, err = sv.Append(safeValues(2)...)
+		assert.Equal(t, anyiter.ErrUnexported, err)
+	})
+}
+
+func TestSafeValue_AsMap(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).AsMap()
+		assertKindError(t, err, "SafeValue.AsMap", reflect.Map)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		m := map[string]int{"a": 1}
+		mv, err := anyiter.NewSafeValue(reflect.ValueOf(m)).AsMap()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, mv.Len())
+		assert.Len(t, mv.Keys(), 1)
+
+		elem, ok := mv.MapIndex(anyiter.NewSafeValue(reflect.ValueOf("a")))
+		assert.True(t, ok)
+		i, _ := elem.Int()
+		assert.Equal(t, int64(1), i)
+		_, ok = mv.MapIndex(anyiter.NewSafeValue(reflect.ValueOf("b")))
+		assert.False(t, ok)
+		_, ok = mv.MapIndex(anyiter.NewSafeValue(reflect.ValueOf(1)))
+		assert.False(t, ok)
+		_, ok = mv.MapIndex(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("set and delete", func(t *testing.T) {
+		m := map[string]int{"a": 1}
+		mv, _ := anyiter.NewSafeValue(reflect.ValueOf(m)).AsMap()
+		entry := safeValues("b", 2)
+		assert.Nil(t, mv.SetMapIndex(entry[0], entry[1]))
+		assert.Equal(t, 2, m["b"])
+		assert.Nil(t, mv.Delete(anyiter.NewSafeValue(reflect.ValueOf("a"))))
+		assert.Equal(t, map[string]int{"b": 2}, m)
+
+		assert.EqualError(t, mv.Delete(anyiter.NewSafeValue(reflect.ValueOf(1))), "key is not assignable to map key type")
+		entry = safeValues("c", "d")
+		assert.EqualError(t, mv.SetMapIndex(entry[0], entry[1]), "element is not assignable to map element type")
+	})
+
+	t.Run("incomparable key", func(t *testing.T) {
+		mv, _ := anyiter.NewSafeValue(reflect.ValueOf(map[any]int{"a": 1})).AsMap()
+		key := anyiter.NewSafeValue(reflect.ValueOf([]int{1}))
+		_, ok := mv.MapIndex(key)
+		assert.False(t, ok)
+		assert.EqualError(t, mv.Delete(key), "key is not comparable")
+	})
+
+	t.Run("range", func(t *testing.T) {
+		mv, _ := anyiter.NewSafeValue(reflect.ValueOf(map[string]int{"a": 1})).AsMap()
+		iter := mv.Range()
+		assert.True(t, iter.Next())
+		key, err := iter.Key()
+		assert.Nil(t, err)
+		k, _ := key.Interface()
+		assert.Equal(t, "a", k)
+		assert.False(t, iter.Next())
+	})
+}
+
+func TestSafeValue_AsStruct(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).AsStruct()
+		assertKindError(t, err, "SafeValue.AsStruct", reflect.Struct)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		sv, err := anyiter.NewSafeValue(reflect.ValueOf(testAccount{Name: "acme"})).AsStruct()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, sv.NumField())
+		assert.Len(t, sv.Fields(), 2)
+
+		field, ok := sv.Field(0)
+		assert.True(t, ok)
+		name := field.String()
+		assert.Equal(t, "acme", name)
+		_, ok = sv.Field(2)
+		assert.False(t, ok)
+
+		field, ok = sv.FieldByName("Name")
+		assert.True(t, ok)
+		name = field.String()
+		assert.Equal(t, "acme", name)
+		_, ok = sv.FieldByName("Missing")
+		assert.False(t, ok)
+	})
+}
+
+func TestSafeValue_AsChan(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).AsChan()
+		assertKindError(t, err, "SafeValue.AsChan", reflect.Chan)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		cv, err := anyiter.NewSafeValue(reflect.ValueOf(make(chan int, 2))).AsChan()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, cv.Cap())
+
+		assert.Nil(t, cv.Send(anyiter.NewSafeValue(reflect.ValueOf(1))))
+		sent, err := cv.TrySend(anyiter.NewSafeValue(reflect.ValueOf(2)))
+		assert.True(t, sent)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, cv.Len())
+
+		x, ok, err := cv.Recv()
+		assert.True(t, ok)
+		assert.Nil(t, err)
+		i, _ := x.Int()
+		assert.Equal(t, int64(1), i)
+
+		x, ok, err = cv.TryRecv()
+		assert.True(t, ok)
+		assert.Nil(t, err)
+		i, _ = x.Int()
+		assert.Equal(t, int64(2), i)
+
+		assert.Nil(t, cv.Close())
+		_, ok, err = cv.Recv()
+		assert.False(t, ok)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSafeValue_AsFunc(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := anyiter.NewSafeValue(reflect.ValueOf(1)).AsFunc()
+		assertKindError(t, err, "SafeValue.AsFunc", reflect.Func)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		fn, err := anyiter.NewSafeValue(reflect.ValueOf(func(a, b int) int { return a + b })).AsFunc()
+		assert.Nil(t, err)
+		out, err := fn.Call(safeValues(1, 2))
+		assert.Nil(t, err)
+		sum, _ := out[0].Int()
+		assert.Equal(t, int64(3), sum)
+	})
+}
@@ -0,0 +1,264 @@
+package anyiter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Strategy is the order in which a deep walk visits values.
+type Strategy int
+
+const (
+	// DepthFirst visits the elements of a value before moving on to its siblings.
+	DepthFirst Strategy = iota
+	// BreadthFirst visits every value at one depth before any value at the next depth.
+	BreadthFirst
+)
+
+// CyclePolicy is what a deep walk does when a value refers back to one of its own ancestors, as in a doubly linked
+// list or a parent back-pointer. Pointers, maps and non-empty slices are compared by identity.
+type CyclePolicy int
+
+const (
+	// SkipCycles leaves out values that refer back to an ancestor.
+	SkipCycles CyclePolicy = iota
+	// ReportCycles visits values that refer back to an ancestor without descending into them. Their Cycle method
+	// returns the path of the ancestor.
+	ReportCycles
+	// FailOnCycles ends the walk at the first value that refers back to an ancestor. Err then returns a *CycleError.
+	FailOnCycles
+)
+
+// CycleError is returned by Err when a walk using FailOnCycles finds a cycle.
+type CycleError struct {
+	// Path is the path of the value that refers back to an ancestor.
+	Path Path
+	// First is the path of the ancestor.
+	First Path
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("cycle: value at %s refers back to %s", pathOrRoot(e.Path), pathOrRoot(e.First))
+}
+
+// pathOrRoot formats p, naming the empty path so it doesn't vanish from error messages.
+func pathOrRoot(p Path) string {
+	if len(p) == 0 {
+		return "root"
+	}
+	return p.String()
+}
+
+// WalkOption configures a deep walk.
+type WalkOption func(*walkConfig)
+
+type walkConfig struct {
+	strategy Strategy
+	maxDepth int
+	cycles   CyclePolicy
+}
+
+// WithStrategy sets the order in which a deep walk visits values. The default is DepthFirst.
+func WithStrategy(strategy Strategy) WalkOption {
+	return func(c *walkConfig) {
+		c.strategy = strategy
+	}
+}
+
+// WithMaxDepth stops a deep walk from descending past depth, so no value deeper than depth is visited.
+// A negative depth, the default, walks the whole value.
+func WithMaxDepth(depth int) WalkOption {
+	return func(c *walkConfig) {
+		c.maxDepth = depth
+	}
+}
+
+// WithCyclePolicy sets what a deep walk does when it finds a cycle. The default is SkipCycles.
+func WithCyclePolicy(policy CyclePolicy) WalkOption {
+	return func(c *walkConfig) {
+		c.cycles = policy
+	}
+}
+
+// NewDeepIter returns an Iter positioned on v that walks every value reachable from v, depth-first unless another
+// Strategy is given. The walk follows nested structs, maps, slices and arrays. Pointers and interfaces are followed to
+// the value they hold without being visited separately, so a pointer's elements are the elements of the value it
+// points to. Strings and channels are visited but not descended into, since walking their runes is rarely wanted and
+// receiving from a channel consumes it.
+func NewDeepIter(v any, opts ...WalkOption) Iter {
+	config := walkConfig{strategy: DepthFirst, maxDepth: -1, cycles: SkipCycles}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return &iter{value: reflect.ValueOf(v), walker: &deepWalker{config: config}}
+}
+
+// Action tells Walk how to go on after a Visitor returns.
+type Action int
+
+const (
+	// Continue goes on with the walk, descending into the current value.
+	Continue Action = iota
+	// SkipChildren goes on with the walk without descending into the current value.
+	SkipChildren
+	// Stop ends the walk. Walk then returns a *StopError.
+	Stop
+)
+
+// Visitor is called by Walk for each value it visits. It may replace the value with Iter.Replace, in which case the
+// walk descends into the replacement.
+type Visitor func(it Iter) Action
+
+// StopError is returned by Walk when a Visitor returns Stop.
+type StopError struct {
+	// Path is the path of the value the Visitor stopped at.
+	Path Path
+}
+
+func (e *StopError) Error() string {
+	return fmt.Sprintf("walk stopped at %s", pathOrRoot(e.Path))
+}
+
+// Walk calls visitor for root and every value reachable from it, in the order NewDeepIter visits them with the
+// same options. It returns a *StopError if visitor stops the walk, the error that ended the walk if there was one,
+// or nil.
+func Walk(root any, visitor Visitor, opts ...WalkOption) error {
+	it := NewDeepIter(root, opts...).(*iter)
+	for {
+		switch visitor(it) {
+		case SkipChildren:
+			it.skipChildren = true
+		case Stop:
+			return &StopError{Path: it.Path()}
+		}
+
+		next := it.Next()
+		if next == nil {
+			return it.Err()
+		}
+		it = next.(*iter)
+	}
+}
+
+// frame is a container whose elements are still being visited.
+type frame struct {
+	elems elements
+	path  Path
+	owner *iter
+}
+
+// deepWalker visits every value reachable from the root. Depth-first walks take the elements of the most recently
+// visited container first, and breadth-first walks those of the least recently visited one.
+type deepWalker struct {
+	config  walkConfig
+	pending []frame
+	failure error
+}
+
+func (w *deepWalker) advance(from *iter) *iter {
+	// from is always the most recently visited value, so in a depth-first walk its elements come next, and in a
+	// breadth-first walk they wait behind those of its siblings.
+	if !from.isCycle && !from.skipChildren && (w.config.maxDepth < 0 || from.Depth() < w.config.maxDepth) {
+		w.pending = append(w.pending, frame{elems: newDeepElements(from.value), path: from.path, owner: from})
+	}
+
+	for len(w.pending) > 0 {
+		i := len(w.pending) - 1
+		if w.config.strategy == BreadthFirst {
+			i = 0
+		}
+
+		f := w.pending[i]
+		elem, ok := f.elems.next()
+		if !ok {
+			w.pending = append(w.pending[:i], w.pending[i+1:]...)
+			continue
+		}
+
+		next := &iter{value: elem.value, path: f.path.append(elem.step), field: elem.field, parent: f.owner, walker: w}
+		if w.visit(next) {
+			return next
+		}
+		if w.failure != nil {
+			w.pending = nil
+		}
+	}
+	return nil
+}
+
+// visit applies the cycle policy to next, reporting whether it should be visited.
+func (w *deepWalker) visit(next *iter) bool {
+	id, ok := identityOf(next.value)
+	if !ok {
+		return true
+	}
+
+	for ancestor := next.parent; ancestor != nil; ancestor = ancestor.parent {
+		if ancestorID, ok := identityOf(ancestor.value); !ok || ancestorID != id {
+			continue
+		}
+
+		switch w.config.cycles {
+		case ReportCycles:
+			next.cycle, next.isCycle = ancestor.path, true
+			return true
+		case FailOnCycles:
+			w.failure = &CycleError{Path: next.path, First: ancestor.path}
+		}
+		return false
+	}
+	return true
+}
+
+func (w *deepWalker) err() error {
+	return w.failure
+}
+
+// identity distinguishes the values a cycle can run through.
+type identity struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// identityOf returns the identity of the pointer, map or slice v holds. Values that can't form a cycle, and those
+// whose address isn't unique, like empty slices and pointers to zero-sized values, have no identity.
+func identityOf(v reflect.Value) (identity, bool) {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type().Elem().Size() == 0 {
+			return identity{}, false
+		}
+		return identity{typ: v.Type(), ptr: v.Pointer()}, true
+	case reflect.Map:
+		if v.IsNil() {
+			return identity{}, false
+		}
+		return identity{typ: v.Type(), ptr: v.Pointer()}, true
+	case reflect.Slice:
+		if v.Len() == 0 || v.Type().Elem().Size() == 0 {
+			return identity{}, false
+		}
+		return identity{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}, true
+	}
+	return identity{}, false
+}
+
+// newDeepElements returns the elements of v that a deep walk descends into. Values that can't be iterated are
+// leaves of the walk rather than failures.
+func newDeepElements(v reflect.Value) elements {
+	v = indirect(v)
+	if v.Kind() == reflect.String || v.Kind() == reflect.Chan {
+		return noElements{}
+	}
+
+	elems, err := newElements(v)
+	if err != nil {
+		return noElements{}
+	}
+	return elems
+}
@@ -0,0 +1,286 @@
+package anyiter_test
+
+import (
+	"errors"
+	"github.com/levisaya/anyiter"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	Price int
+}
+
+type testOrder struct {
+	Items map[string]*testItem
+}
+
+type testAccount struct {
+	Name   string
+	Orders []testOrder
+}
+
+// collectPaths returns the path of every value visited after it.
+func collectPaths(it anyiter.Iter) []string {
+	var paths []string
+	for it = it.Next(); it != nil; it = it.Next() {
+		paths = append(paths, it.Path().String())
+	}
+	return paths
+}
+
+func TestNewDeepIter(t *testing.T) {
+	t.Run("root", func(t *testing.T) {
+		it := anyiter.NewDeepIter(testAccount{})
+		assert.Equal(t, "", it.Path().String())
+	})
+
+	t.Run("depth first", func(t *testing.T) {
+		account := testAccount{
+			Name: "acme",
+			Orders: []testOrder{
+				{Items: map[string]*testItem{"sku": {Price: 5}}},
+				{},
+			},
+		}
+		assert.Equal(t, []string{
+			".Name",
+			".Orders",
+			".Orders[0]",
+			".Orders[0].Items",
+			`.Orders[0].Items["sku"]`,
+			`.Orders[0].Items["sku"].Price`,
+			".Orders[1]",
+			".Orders[1].Items",
+		}, collectPaths(anyiter.NewDeepIter(account)))
+	})
+
+	t.Run("values", func(t *testing.T) {
+		account := testAccount{Orders: []testOrder{{Items: map[string]*testItem{"sku": {Price: 5}}}}}
+		var price anyiter.Iter
+		for it := anyiter.NewDeepIter(&account).Next(); it != nil; it = it.Next() {
+			if it.Path().String() == `.Orders[0].Items["sku"].Price` {
+				price = it
+			}
+		}
+		assert.NotNil(t, price)
+		p, err := price.Value().Int()
+		assert.Equal(t, int64(5), p)
+		assert.Nil(t, err)
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		v := []any{[]int{1}, "ab"}
+		assert.Equal(t, []string{"[0]", "[0][0]", "[1]"}, collectPaths(anyiter.NewDeepIter(v)))
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		v := []*testItem{nil}
+		assert.Equal(t, []string{"[0]"}, collectPaths(anyiter.NewDeepIter(v)))
+	})
+
+	t.Run("not iterable", func(t *testing.T) {
+		assert.Nil(t, anyiter.NewDeepIter(1).Next())
+	})
+}
+
+func TestNewDeepIter_BreadthFirst(t *testing.T) {
+	account := testAccount{
+		Name: "acme",
+		Orders: []testOrder{
+			{Items: map[string]*testItem{"sku": {Price: 5}}},
+			{},
+		},
+	}
+
+	t.Run("order", func(t *testing.T) {
+		it := anyiter.NewDeepIter(account, anyiter.WithStrategy(anyiter.BreadthFirst))
+		assert.Equal(t, []string{
+			".Name",
+			".Orders",
+			".Orders[0]",
+			".Orders[1]",
+			".Orders[0].Items",
+			".Orders[1].Items",
+			`.Orders[0].Items["sku"]`,
+			`.Orders[0].Items["sku"].Price`,
+		}, collectPaths(it))
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		var depths []int
+		it := anyiter.NewDeepIter(account, anyiter.WithStrategy(anyiter.BreadthFirst))
+		assert.Equal(t, 0, it.Depth())
+		for it = it.Next(); it != nil; it = it.Next() {
+			depths = append(depths, it.Depth())
+		}
+		assert.Equal(t, []int{1, 1, 2, 2, 3, 3, 4, 5}, depths)
+	})
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	account := testAccount{
+		Name:   "acme",
+		Orders: []testOrder{{Items: map[string]*testItem{"sku": {Price: 5}}}},
+	}
+
+	t.Run("depth first", func(t *testing.T) {
+		it := anyiter.NewDeepIter(account, anyiter.WithMaxDepth(2))
+		assert.Equal(t, []string{".Name", ".Orders", ".Orders[0]"}, collectPaths(it))
+	})
+
+	t.Run("breadth first", func(t *testing.T) {
+		it := anyiter.NewDeepIter(account, anyiter.WithStrategy(anyiter.BreadthFirst), anyiter.WithMaxDepth(1))
+		assert.Equal(t, []string{".Name", ".Orders"}, collectPaths(it))
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Nil(t, anyiter.NewDeepIter(account, anyiter.WithMaxDepth(0)).Next())
+	})
+}
+
+type testNode struct {
+	Name string
+	Prev *testNode
+	Next *testNode
+}
+
+func TestWithCyclePolicy(t *testing.T) {
+	// newList returns a two node doubly linked list.
+	newList := func() *testNode {
+		first := &testNode{Name: "first"}
+		first.Next = &testNode{Name: "second", Prev: first}
+		return first
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		it := anyiter.NewDeepIter(newList())
+		assert.Equal(t, []string{".Name", ".Prev", ".Next", ".Next.Name", ".Next.Next"}, collectPaths(it))
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("report", func(t *testing.T) {
+		var cycles []string
+		it := anyiter.NewDeepIter(newList(), anyiter.WithCyclePolicy(anyiter.ReportCycles))
+		for next := it.Next(); next != nil; next = next.Next() {
+			if first, ok := next.Cycle(); ok {
+				cycles = append(cycles, next.Path().String()+" -> "+first.String())
+			}
+		}
+		assert.Equal(t, []string{".Next.Prev -> "}, cycles)
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		it := anyiter.NewDeepIter(newList(), anyiter.WithCyclePolicy(anyiter.FailOnCycles))
+		assert.Equal(t, []string{".Name", ".Prev", ".Next", ".Next.Name"}, collectPaths(it))
+
+		var cycleErr *anyiter.CycleError
+		assert.True(t, errors.As(it.Err(), &cycleErr))
+		assert.Equal(t, ".Next.Prev", cycleErr.Path.String())
+		assert.Equal(t, "", cycleErr.First.String())
+		assert.Equal(t, "cycle: value at .Next.Prev refers back to root", it.Err().Error())
+	})
+
+	t.Run("breadth first", func(t *testing.T) {
+		it := anyiter.NewDeepIter(newList(), anyiter.WithStrategy(anyiter.BreadthFirst))
+		assert.Equal(t, []string{".Name", ".Prev", ".Next", ".Next.Name", ".Next.Next"}, collectPaths(it))
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		m := map[string]any{}
+		m["self"] = m
+		it := anyiter.NewDeepIter(m, anyiter.WithCyclePolicy(anyiter.ReportCycles))
+		next := it.Next()
+		first, ok := next.Cycle()
+		assert.True(t, ok)
+		assert.Equal(t, "", first.String())
+		assert.Nil(t, next.Next())
+	})
+
+	t.Run("shared values are not cycles", func(t *testing.T) {
+		shared := &testItem{Price: 5}
+		v := []*testItem{shared, shared}
+		assert.Equal(t, []string{"[0]", "[0].Price", "[1]", "[1].Price"}, collectPaths(anyiter.NewDeepIter(v)))
+	})
+}
+
+func TestWalk(t *testing.T) {
+	account := testAccount{
+		Name:   "acme",
+		Orders: []testOrder{{Items: map[string]*testItem{"sku": {Price: 5}}}},
+	}
+
+	t.Run("continue", func(t *testing.T) {
+		var paths []string
+		err := anyiter.Walk(account, func(it anyiter.Iter) anyiter.Action {
+			paths = append(paths, it.Path().String())
+			return anyiter.Continue
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"",
+			".Name",
+			".Orders",
+			".Orders[0]",
+			".Orders[0].Items",
+			`.Orders[0].Items["sku"]`,
+			`.Orders[0].Items["sku"].Price`,
+		}, paths)
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		var paths []string
+		err := anyiter.Walk(account, func(it anyiter.Iter) anyiter.Action {
+			paths = append(paths, it.Path().String())
+			if it.Path().String() == ".Orders" {
+				return anyiter.SkipChildren
+			}
+			return anyiter.Continue
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"", ".Name", ".Orders"}, paths)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		err := anyiter.Walk(account, func(it anyiter.Iter) anyiter.Action {
+			if it.Depth() == 3 {
+				return anyiter.Stop
+			}
+			return anyiter.Continue
+		})
+
+		var stopErr *anyiter.StopError
+		assert.True(t, errors.As(err, &stopErr))
+		assert.Equal(t, ".Orders[0].Items", stopErr.Path.String())
+		assert.Equal(t, "walk stopped at .Orders[0].Items", err.Error())
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		account := testAccount{Orders: []testOrder{{}}}
+		var paths []string
+		err := anyiter.Walk(&account, func(it anyiter.Iter) anyiter.Action {
+			paths = append(paths, it.Path().String())
+			if it.Path().String() == ".Orders[0].Items" {
+				items := map[string]*testItem{"new": {Price: 1}}
+				assert.Nil(t, it.Replace(anyiter.NewSafeValue(reflect.ValueOf(items))))
+			}
+			return anyiter.Continue
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, account.Orders[0].Items["new"].Price)
+		assert.Contains(t, paths, `.Orders[0].Items["new"].Price`)
+	})
+
+	t.Run("cycle error", func(t *testing.T) {
+		node := &testNode{}
+		node.Next = node
+		err := anyiter.Walk(node, func(it anyiter.Iter) anyiter.Action {
+			return anyiter.Continue
+		}, anyiter.WithCyclePolicy(anyiter.FailOnCycles))
+
+		var cycleErr *anyiter.CycleError
+		assert.True(t, errors.As(err, &cycleErr))
+	})
+}